@@ -0,0 +1,150 @@
+package blockatlas
+
+import "context"
+
+// The context variants of the APIs are used to serve requests.
+// Platforms that only implement the variants without context are
+// adapted by the functions below, their lookups can't be canceled.
+
+// AsTxContextAPI returns the transaction lookups of a platform
+func AsTxContextAPI(p Platform) (TxContextAPI, bool) {
+	if api, ok := p.(TxContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(TxAPI); ok {
+		return txAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsPagedTxContextAPI returns the paginated transaction lookups of a platform
+func AsPagedTxContextAPI(p Platform) (PagedTxContextAPI, bool) {
+	if api, ok := p.(PagedTxContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(PagedTxAPI); ok {
+		return pagedTxAdapter{txAdapter{api}, api}, true
+	}
+	return nil, false
+}
+
+// AsPendingTxContextAPI returns the pending transaction lookups of a platform
+func AsPendingTxContextAPI(p Platform) (PendingTxContextAPI, bool) {
+	if api, ok := p.(PendingTxContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(PendingTxAPI); ok {
+		return pendingTxAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsTxByIDContextAPI returns the single transaction lookups of a platform
+func AsTxByIDContextAPI(p Platform) (TxByIDContextAPI, bool) {
+	if api, ok := p.(TxByIDContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(TxByIDAPI); ok {
+		return txByIDAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsBalanceContextAPI returns the balance lookups of a platform
+func AsBalanceContextAPI(p Platform) (BalanceContextAPI, bool) {
+	if api, ok := p.(BalanceContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(BalanceAPI); ok {
+		return balanceAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsTokenTxContextAPI returns the token transaction lookups of a platform
+func AsTokenTxContextAPI(p Platform) (TokenTxContextAPI, bool) {
+	if api, ok := p.(TokenTxContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(TokenTxAPI); ok {
+		return tokenTxAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsTokenListContextAPI returns the token list lookups of a platform
+func AsTokenListContextAPI(p Platform) (TokenListContextAPI, bool) {
+	if api, ok := p.(TokenListContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(TokenListAPI); ok {
+		return tokenListAdapter{api}, true
+	}
+	return nil, false
+}
+
+// AsBlockContextAPI returns the block lookups of a platform
+func AsBlockContextAPI(p Platform) (BlockContextAPI, bool) {
+	if api, ok := p.(BlockContextAPI); ok {
+		return api, true
+	}
+	if api, ok := p.(BlockAPI); ok {
+		return blockAdapter{api}, true
+	}
+	return nil, false
+}
+
+type txAdapter struct{ TxAPI }
+
+func (a txAdapter) GetTxsByAddressContext(_ context.Context, address string) (TxPage, error) {
+	return a.GetTxsByAddress(address)
+}
+
+type pagedTxAdapter struct {
+	txAdapter
+	api PagedTxAPI
+}
+
+func (a pagedTxAdapter) GetTxPageByAddressContext(_ context.Context, address string, cursor string) (TxPage, string, error) {
+	return a.api.GetTxPageByAddress(address, cursor)
+}
+
+type pendingTxAdapter struct{ PendingTxAPI }
+
+func (a pendingTxAdapter) GetPendingTxsByAddressContext(_ context.Context, address string) (TxPage, error) {
+	return a.GetPendingTxsByAddress(address)
+}
+
+type txByIDAdapter struct{ TxByIDAPI }
+
+func (a txByIDAdapter) GetTxContext(_ context.Context, id string) (*Tx, error) {
+	return a.GetTx(id)
+}
+
+type balanceAdapter struct{ BalanceAPI }
+
+func (a balanceAdapter) GetBalanceContext(_ context.Context, address string) (Amount, error) {
+	return a.GetBalance(address)
+}
+
+type tokenTxAdapter struct{ TokenTxAPI }
+
+func (a tokenTxAdapter) GetTokenTxsByAddressContext(_ context.Context, address string, token string) (TxPage, error) {
+	return a.GetTokenTxsByAddress(address, token)
+}
+
+type tokenListAdapter struct{ TokenListAPI }
+
+func (a tokenListAdapter) GetTokenListByAddressContext(_ context.Context, address string) (TokenPage, error) {
+	return a.GetTokenListByAddress(address)
+}
+
+type blockAdapter struct{ BlockAPI }
+
+func (a blockAdapter) CurrentBlockNumberContext(_ context.Context) (int64, error) {
+	return a.CurrentBlockNumber()
+}
+
+func (a blockAdapter) GetBlockByNumberContext(_ context.Context, num int64) (*Block, error) {
+	return a.GetBlockByNumber(num)
+}
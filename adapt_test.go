@@ -0,0 +1,60 @@
+package blockatlas
+
+import (
+	"context"
+	"github.com/trustwallet/blockatlas/coin"
+	"testing"
+)
+
+// legacyPlatform implements the APIs without context only
+type legacyPlatform struct{}
+
+func (legacyPlatform) Init() error     { return nil }
+func (legacyPlatform) Coin() coin.Coin { return coin.Coins[coin.XRP] }
+
+func (legacyPlatform) GetTxsByAddress(address string) (TxPage, error) {
+	return TxPage{{ID: address}}, nil
+}
+
+func (legacyPlatform) GetTxPageByAddress(address string, cursor string) (TxPage, string, error) {
+	return TxPage{{ID: address + cursor}}, "next", nil
+}
+
+func (legacyPlatform) GetBalance(address string) (Amount, error) {
+	return "1", nil
+}
+
+func TestAdaptContextAPIs(t *testing.T) {
+	ctx := context.Background()
+	p := legacyPlatform{}
+
+	pagedAPI, ok := AsPagedTxContextAPI(p)
+	if !ok {
+		t.Fatal("expected paged transaction lookups to be adapted")
+	}
+	if txs, err := pagedAPI.GetTxsByAddressContext(ctx, "a"); err != nil || txs[0].ID != "a" {
+		t.Errorf("unexpected transactions %v %v", txs, err)
+	}
+	if txs, next, err := pagedAPI.GetTxPageByAddressContext(ctx, "a", "1"); err != nil || txs[0].ID != "a1" || next != "next" {
+		t.Errorf("unexpected page %v %s %v", txs, next, err)
+	}
+	if pagedAPI.Coin().ID != coin.XRP {
+		t.Errorf("expected coin of the platform, got %d", pagedAPI.Coin().ID)
+	}
+
+	balanceAPI, ok := AsBalanceContextAPI(p)
+	if !ok {
+		t.Fatal("expected balance lookups to be adapted")
+	}
+	if balance, err := balanceAPI.GetBalanceContext(ctx, "a"); err != nil || balance != "1" {
+		t.Errorf("unexpected balance %s %v", balance, err)
+	}
+
+	// Capabilities the platform lacks are not adapted
+	if _, ok := AsTokenListContextAPI(p); ok {
+		t.Error("expected no token list lookups")
+	}
+	if _, ok := AsBlockContextAPI(p); ok {
+		t.Error("expected no block lookups")
+	}
+}
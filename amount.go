@@ -0,0 +1,143 @@
+package blockatlas
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseAmount reads a decimal string (e.g. "12.345") of a currency
+// with the given number of decimals into the smallest unit.
+// Fails on negative or malformed numbers and on fractional
+// digits beyond the currency's precision other than zeros.
+//  - ParseAmount("12.345", 8) => "1234500000"
+//  - ParseAmount("0.1", 0) => error
+func ParseAmount(dec string, decimals uint) (Amount, error) {
+	if !matchNumber.MatchString(dec) {
+		return "", fmt.Errorf("not a regular decimal number: %s", dec)
+	}
+
+	integer, fraction := dec, ""
+	if i := strings.IndexRune(dec, '.'); i != -1 {
+		integer, fraction = dec[:i], dec[i+1:]
+	}
+
+	// Zeros beyond the precision do not change the value
+	if uint(len(fraction)) > decimals {
+		if strings.TrimRight(fraction[decimals:], "0") != "" {
+			return "", fmt.Errorf("more than %d decimals: %s", decimals, dec)
+		}
+		fraction = fraction[:decimals]
+	}
+	fraction += strings.Repeat("0", int(decimals)-len(fraction))
+
+	var i big.Int
+	if _, ok := i.SetString(integer+fraction, 10); !ok {
+		return "", fmt.Errorf("not a regular decimal number: %s", dec)
+	}
+	return Amount(i.String()), nil
+}
+
+// ParseHexAmount reads a hexadecimal integer (e.g. "0x1f") into an amount
+func ParseHexAmount(hex string) (Amount, error) {
+	var i big.Int
+	if _, ok := i.SetString(hex, 0); !ok {
+		return "", fmt.Errorf("invalid hex: %s", hex)
+	}
+	return NewAmount(&i)
+}
+
+// NewAmount returns the amount of a big integer.
+// Fails if the integer is negative.
+func NewAmount(i *big.Int) (Amount, error) {
+	if i.Sign() < 0 {
+		return "", fmt.Errorf("negative amount: %s", i)
+	}
+	return Amount(i.String()), nil
+}
+
+// AmountFromUint64 returns the amount of an integer
+func AmountFromUint64(u uint64) Amount {
+	return Amount(new(big.Int).SetUint64(u).String())
+}
+
+// BigInt returns the amount as big integer.
+// Fails if the amount is not a positive decimal integer string.
+func (a Amount) BigInt() (*big.Int, error) {
+	var i big.Int
+	if _, ok := i.SetString(string(a), 10); !ok || i.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %s", string(a))
+	}
+	return &i, nil
+}
+
+// Add returns a + b
+func (a Amount) Add(b Amount) (Amount, error) {
+	x, y, err := bigInts(a, b)
+	if err != nil {
+		return "", err
+	}
+	return NewAmount(x.Add(x, y))
+}
+
+// Sub returns a - b.
+// Fails if b is greater than a.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	x, y, err := bigInts(a, b)
+	if err != nil {
+		return "", err
+	}
+	return NewAmount(x.Sub(x, y))
+}
+
+// Mul returns a * b
+func (a Amount) Mul(b Amount) (Amount, error) {
+	x, y, err := bigInts(a, b)
+	if err != nil {
+		return "", err
+	}
+	return NewAmount(x.Mul(x, y))
+}
+
+// Cmp compares a and b and returns
+// -1 if a < b, 0 if a == b and +1 if a > b
+func (a Amount) Cmp(b Amount) (int, error) {
+	x, y, err := bigInts(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return x.Cmp(y), nil
+}
+
+// Format returns the amount in the main unit of a currency
+// with the given number of decimals, without trailing zeros.
+//  - Amount("1234500000").Format(8) => "12.345"
+func (a Amount) Format(decimals uint) (string, error) {
+	i, err := a.BigInt()
+	if err != nil {
+		return "", err
+	}
+
+	digits := i.String()
+	if pad := int(decimals) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	comma := len(digits) - int(decimals)
+	integer, fraction := digits[:comma], strings.TrimRight(digits[comma:], "0")
+	if fraction == "" {
+		return integer, nil
+	}
+	return integer + "." + fraction, nil
+}
+
+func bigInts(a, b Amount) (*big.Int, *big.Int, error) {
+	x, err := a.BigInt()
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := b.BigInt()
+	if err != nil {
+		return nil, nil, err
+	}
+	return x, y, nil
+}
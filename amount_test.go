@@ -0,0 +1,76 @@
+package blockatlas
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		dec      string
+		decimals uint
+		expected Amount
+		ok       bool
+	}{
+		{"12.345", 8, "1234500000", true},
+		{"12.3450000000", 8, "1234500000", true},
+		{"0.0000001", 7, "1", true},
+		{"100", 0, "100", true},
+		{"007", 2, "700", true},
+		{"0.1", 0, "", false},
+		{"-1", 8, "", false},
+		{"1,5", 8, "", false},
+		{"", 8, "", false},
+	}
+
+	for _, test := range tests {
+		got, err := ParseAmount(test.dec, test.decimals)
+		if (err == nil) != test.ok {
+			t.Errorf("%s: unexpected error state %v", test.dec, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.dec, test.expected, got)
+		}
+	}
+}
+
+func TestAmount_Format(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		decimals uint
+		expected string
+	}{
+		{"1234500000", 8, "12.345"},
+		{"1", 7, "0.0000001"},
+		{"100", 0, "100"},
+		{"0", 18, "0"},
+	}
+
+	for _, test := range tests {
+		got, err := test.amount.Format(test.decimals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.amount, test.expected, got)
+		}
+	}
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	sum, err := Amount("99999999999999999999").Add("1")
+	if err != nil || sum != "100000000000000000000" {
+		t.Errorf("add: got %s, %v", sum, err)
+	}
+	if _, err := Amount("1").Sub("2"); err == nil {
+		t.Error("sub: negative result accepted")
+	}
+	product, err := Amount("21000").Mul("1000000000")
+	if err != nil || product != "21000000000000" {
+		t.Errorf("mul: got %s, %v", product, err)
+	}
+	if cmp, err := Amount("10").Cmp("9"); err != nil || cmp != 1 {
+		t.Errorf("cmp: got %d, %v", cmp, err)
+	}
+	if _, err := Amount("1.5").Add("1"); err == nil {
+		t.Error("malformed amount accepted")
+	}
+}
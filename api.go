@@ -1,6 +1,7 @@
 package blockatlas
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/trustwallet/blockatlas/coin"
 )
@@ -16,23 +17,130 @@ type Platform interface {
 	Coin() coin.Coin
 }
 
+// AddressValidator validates addresses of a platform
+// and converts them into their canonical form.
+// Invalid addresses are reported as ErrInvalidAddr.
+type AddressValidator interface {
+	Platform
+	ValidateAddress(address string) (canonical string, err error)
+}
+
+// ValidateAddress converts an address into the canonical form of
+// a platform. Platforms without AddressValidator accept all addresses.
+func ValidateAddress(p Platform, address string) (string, error) {
+	validator, ok := p.(AddressValidator)
+	if !ok {
+		return address, nil
+	}
+	return validator.ValidateAddress(address)
+}
+
+// CanonicalAddress converts an address into the canonical form
+// of a platform. Addresses the platform rejects are kept as is.
+func CanonicalAddress(p Platform, address string) string {
+	canonical, err := ValidateAddress(p, address)
+	if err != nil {
+		return address
+	}
+	return canonical
+}
+
 // TxAPI provides transaction lookups
 type TxAPI interface {
 	Platform
 	GetTxsByAddress(address string) (TxPage, error)
 }
 
+// TxContextAPI provides transaction lookups
+// that are canceled when the context is done
+type TxContextAPI interface {
+	Platform
+	GetTxsByAddressContext(ctx context.Context, address string) (TxPage, error)
+}
+
+// PagedTxAPI provides paginated transaction lookups.
+// The cursor is opaque to the caller: An empty cursor
+// requests the newest page, an empty next cursor
+// signals that there are no older transactions.
+type PagedTxAPI interface {
+	TxAPI
+	GetTxPageByAddress(address string, cursor string) (page TxPage, next string, err error)
+}
+
+// PagedTxContextAPI provides paginated transaction lookups
+// that are canceled when the context is done
+type PagedTxContextAPI interface {
+	TxContextAPI
+	GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (page TxPage, next string, err error)
+}
+
+// PendingTxAPI provides lookups of transactions of an address
+// that have been broadcast but not yet included in a block
+type PendingTxAPI interface {
+	Platform
+	GetPendingTxsByAddress(address string) (TxPage, error)
+}
+
+// PendingTxContextAPI provides lookups of pending transactions
+// that are canceled when the context is done
+type PendingTxContextAPI interface {
+	Platform
+	GetPendingTxsByAddressContext(ctx context.Context, address string) (TxPage, error)
+}
+
+// TxByIDAPI provides lookups of a single transaction by its ID
+type TxByIDAPI interface {
+	Platform
+	GetTx(id string) (*Tx, error)
+}
+
+// TxByIDContextAPI provides lookups of a single transaction
+// that are canceled when the context is done
+type TxByIDContextAPI interface {
+	Platform
+	GetTxContext(ctx context.Context, id string) (*Tx, error)
+}
+
+// BalanceAPI provides balance lookups
+// of the native currency of a platform
 type BalanceAPI interface {
 	Platform
 	GetBalance(address string) (Amount, error)
 }
 
+// BalanceContextAPI provides balance lookups
+// that are canceled when the context is done
+type BalanceContextAPI interface {
+	Platform
+	GetBalanceContext(ctx context.Context, address string) (Amount, error)
+}
+
 // TokenTxAPI provides token transaction lookups
 type TokenTxAPI interface {
 	Platform
 	GetTokenTxsByAddress(address string, token string) (TxPage, error)
 }
 
+// TokenTxContextAPI provides token transaction lookups
+// that are canceled when the context is done
+type TokenTxContextAPI interface {
+	Platform
+	GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (TxPage, error)
+}
+
+// TokenListAPI provides lookups of the tokens held by an address
+type TokenListAPI interface {
+	Platform
+	GetTokenListByAddress(address string) (TokenPage, error)
+}
+
+// TokenListContextAPI provides token list lookups
+// that are canceled when the context is done
+type TokenListContextAPI interface {
+	Platform
+	GetTokenListByAddressContext(ctx context.Context, address string) (TokenPage, error)
+}
+
 // BlockAPI provides block information and lookups
 type BlockAPI interface {
 	Platform
@@ -40,6 +148,14 @@ type BlockAPI interface {
 	GetBlockByNumber(num int64) (*Block, error)
 }
 
+// BlockContextAPI provides block lookups
+// that are canceled when the context is done
+type BlockContextAPI interface {
+	Platform
+	CurrentBlockNumberContext(ctx context.Context) (int64, error)
+	GetBlockByNumberContext(ctx context.Context, num int64) (*Block, error)
+}
+
 // CustomAPI provides custom HTTP routes
 type CustomAPI interface {
 	Platform
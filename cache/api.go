@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"github.com/trustwallet/blockatlas"
+)
+
+// TxAPI caches the transaction lookups of a platform
+type TxAPI struct {
+	blockatlas.TxContextAPI
+	Cache *Cache
+}
+
+func (a *TxAPI) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	return cachedTxs(a.Cache, a.Cache.key("txs", address), func() (blockatlas.TxPage, error) {
+		return a.TxContextAPI.GetTxsByAddressContext(ctx, address)
+	})
+}
+
+// PagedTxAPI caches the paginated transaction lookups of a platform
+type PagedTxAPI struct {
+	blockatlas.PagedTxContextAPI
+	Cache *Cache
+}
+
+func (a *PagedTxAPI) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	return cachedTxs(a.Cache, a.Cache.key("txs", address), func() (blockatlas.TxPage, error) {
+		return a.PagedTxContextAPI.GetTxsByAddressContext(ctx, address)
+	})
+}
+
+func (a *PagedTxAPI) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	key := a.Cache.key("txs", address, cursor)
+	var page struct {
+		Txs  []blockatlas.Tx `json:"txs"`
+		Next string          `json:"next"`
+	}
+	if a.Cache.get(key, &page) {
+		return page.Txs, page.Next, nil
+	}
+	txs, next, err := a.PagedTxContextAPI.GetTxPageByAddressContext(ctx, address, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	page.Txs, page.Next = txs, next
+	a.Cache.set(key, &page)
+	return txs, next, nil
+}
+
+// TokenTxAPI caches the token transaction lookups of a platform
+type TokenTxAPI struct {
+	blockatlas.TokenTxContextAPI
+	Cache *Cache
+}
+
+func (a *TokenTxAPI) GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (blockatlas.TxPage, error) {
+	return cachedTxs(a.Cache, a.Cache.key("token_txs", address, token), func() (blockatlas.TxPage, error) {
+		return a.TokenTxContextAPI.GetTokenTxsByAddressContext(ctx, address, token)
+	})
+}
+
+// cachedTxs returns the cached transactions of a key
+// or caches the transactions returned by load
+func cachedTxs(c *Cache, key string, load func() (blockatlas.TxPage, error)) (blockatlas.TxPage, error) {
+	// Cached as plain list, pages are encoded with their total
+	var txs []blockatlas.Tx
+	if c.get(key, &txs) {
+		return txs, nil
+	}
+	page, err := load()
+	if err != nil {
+		return nil, err
+	}
+	txs = page
+	c.set(key, txs)
+	return page, nil
+}
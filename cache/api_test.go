@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"testing"
+	"time"
+)
+
+type txPlatform struct{ calls int }
+
+func (p *txPlatform) Init() error     { return nil }
+func (p *txPlatform) Coin() coin.Coin { return coin.Coins[coin.XLM] }
+func (p *txPlatform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	p.calls++
+	return blockatlas.TxPage{{
+		ID:   "tx",
+		Coin: coin.XLM,
+		From: address,
+		Fee:  "100",
+		Meta: blockatlas.Transfer{Value: "1"},
+	}}, nil
+}
+
+func TestTxAPI(t *testing.T) {
+	p := &txPlatform{}
+	api := &TxAPI{TxContextAPI: p, Cache: &Cache{
+		Store:  NewLRU(10),
+		Handle: "cache-test",
+		TTL:    time.Minute,
+	}}
+
+	for i := 0; i < 3; i++ {
+		page, err := api.GetTxsByAddressContext(context.Background(), "GA")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 1 || page[0].From != "GA" {
+			t.Fatalf("unexpected page %+v", page)
+		}
+		if transfer, ok := page[0].Meta.(blockatlas.Transfer); i > 0 && (!ok || transfer.Value != "1") {
+			t.Errorf("cached transfer not decoded, got %#v", page[0].Meta)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("expected a single lookup, got %d", p.calls)
+	}
+
+	hits := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("cache-test", metrics.ResultHit))
+	misses := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("cache-test", metrics.ResultMiss))
+	if hits != 2 || misses != 1 {
+		t.Errorf("expected 2 hits and 1 miss, got %v and %v", hits, misses)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	if ttl := TTL(coin.Coin{Handle: "test", BlockTime: 5000}); ttl != 5*time.Second {
+		t.Errorf("expected TTL of a block time, got %s", ttl)
+	}
+	if ttl := TTL(coin.Coin{Handle: "test"}); ttl != DefaultTTL {
+		t.Errorf("expected default TTL, got %s", ttl)
+	}
+}
@@ -0,0 +1,87 @@
+// Package cache caches the responses of platforms.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"time"
+)
+
+// DefaultTTL is the time responses are cached
+// for coins with an unknown block time
+const DefaultTTL = 10 * time.Second
+
+// Store keeps cached values until they expire
+type Store interface {
+	// Get returns the value of a key, false if there is none
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// Cache stores the responses of a platform
+type Cache struct {
+	Store  Store
+	Handle string
+	TTL    time.Duration
+}
+
+// New returns the cache of a coin.
+// Responses are cached for a block time unless
+// <handle>.cache_ttl is set.
+func New(store Store, c coin.Coin) *Cache {
+	return &Cache{Store: store, Handle: c.Handle, TTL: TTL(c)}
+}
+
+// TTL returns the time the responses of a coin are cached
+func TTL(c coin.Coin) time.Duration {
+	key := fmt.Sprintf("%s.cache_ttl", c.Handle)
+	if viper.IsSet(key) {
+		return viper.GetDuration(key)
+	}
+	if c.BlockTime == 0 {
+		return DefaultTTL
+	}
+	return time.Duration(c.BlockTime) * time.Millisecond
+}
+
+// key returns the key of a lookup
+//  - ("nimiq", "txs", "NQ...") => "ATLAS_CACHE:nimiq:txs:NQ..."
+func (c *Cache) key(parts ...string) string {
+	key := "ATLAS_CACHE:" + c.Handle
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key
+}
+
+// get decodes the cached value of a key into v.
+// Returns false on a miss.
+func (c *Cache) get(key string, v interface{}) bool {
+	data, ok, err := c.Store.Get(key)
+	if err != nil {
+		logrus.WithError(err).WithField("platform", c.Handle).
+			Warn("Failed to read from cache")
+	}
+	if ok && json.Unmarshal(data, v) == nil {
+		metrics.CacheLookups.WithLabelValues(c.Handle, metrics.ResultHit).Inc()
+		return true
+	}
+	metrics.CacheLookups.WithLabelValues(c.Handle, metrics.ResultMiss).Inc()
+	return false
+}
+
+// set caches the encoded value of a key
+func (c *Cache) set(key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = c.Store.Set(key, data, c.TTL)
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("platform", c.Handle).
+			Warn("Failed to write to cache")
+	}
+}
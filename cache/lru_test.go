@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	lru := NewLRU(2)
+	_ = lru.Set("a", []byte("1"), time.Minute)
+	_ = lru.Set("b", []byte("2"), time.Minute)
+
+	// Touch a, b becomes the least recently used entry
+	if v, ok, _ := lru.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("expected a = 1, got %q %v", v, ok)
+	}
+	_ = lru.Set("c", []byte("3"), time.Minute)
+	if _, ok, _ := lru.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if lru.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", lru.Len())
+	}
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	lru := NewLRU(2)
+	_ = lru.Set("a", []byte("1"), -time.Second)
+	if _, ok, _ := lru.Get("a"); ok {
+		t.Error("expected expired entry to be missing")
+	}
+	if lru.Len() != 0 {
+		t.Errorf("expected expired entry to be removed, got %d entries", lru.Len())
+	}
+}
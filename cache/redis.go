@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"github.com/go-redis/redis"
+	"time"
+)
+
+// Redis is a store shared by all instances of the API
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis returns a store backed by Redis
+func NewRedis(client *redis.Client) *Redis {
+	return &Redis{client: client}
+}
+
+func (r *Redis) Get(key string) ([]byte, bool, error) {
+	value, err := r.client.Get(key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(key, value, ttl).Err()
+}
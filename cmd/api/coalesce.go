@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"github.com/trustwallet/blockatlas"
+	"strings"
+	"sync"
+)
+
+// flights coalesces identical concurrent lookups of the generic routes
+var flights = &flightGroup{calls: make(map[string]*flight)}
+
+// flightGroup runs a lookup once for all concurrent callers with the same key
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a running lookup
+type flight struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs fn once for all concurrent callers with the same key and
+// shares its result. fn runs with a context of its own that keeps the
+// deadline of the first caller and is canceled once all callers are gone.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	f, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := detach(ctx)
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go func() {
+			f.val, f.err = fn(callCtx)
+			cancel()
+			g.forget(key, f)
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		abandoned := f.waiters == 0
+		g.mu.Unlock()
+		if abandoned {
+			// Later callers start over instead of joining a canceled lookup
+			g.forget(key, f)
+			f.cancel()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) forget(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
+
+// detach returns a context that is independent of the
+// cancellation of ctx but has the same deadline
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(context.Background(), deadline)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// flightKey identifies a lookup by platform, method and arguments
+func flightKey(handle string, method string, args ...string) string {
+	return strings.Join(append([]string{handle, method}, args...), "\x00")
+}
+
+// coalesceTxs runs a transaction lookup once for identical concurrent
+// requests. Every caller gets a copy of the page it can modify.
+func coalesceTxs(ctx context.Context, key string, lookup func(ctx context.Context) (blockatlas.TxPage, error)) (blockatlas.TxPage, error) {
+	v, err := flights.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return lookup(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(blockatlas.TxPage(nil), v.(blockatlas.TxPage)...), nil
+}
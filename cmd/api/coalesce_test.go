@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroup_Do(t *testing.T) {
+	g := &flightGroup{calls: make(map[string]*flight)}
+	var calls int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "result", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = g.do(context.Background(), "key", fn)
+		}(i)
+	}
+	// Let all callers join the running lookup
+	for {
+		g.mu.Lock()
+		f := g.calls["key"]
+		joined := f != nil && f.waiters == len(results)
+		g.mu.Unlock()
+		if joined {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected a single lookup, got %d", calls)
+	}
+	for i, r := range results {
+		if r != "result" {
+			t.Errorf("caller %d: expected shared result, got %v", i, r)
+		}
+	}
+}
+
+func TestFlightGroup_Abandoned(t *testing.T) {
+	g := &flightGroup{calls: make(map[string]*flight)}
+	canceled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := g.do(ctx, "key", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+	if err != context.Canceled {
+		t.Errorf("expected canceled caller, got %v", err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("lookup not canceled after all callers left")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.calls) != 0 {
+		t.Error("abandoned lookup still joinable")
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/util"
+	"strconv"
+	"strings"
+)
+
+// Values accepted by the filters of transaction routes
+var (
+	filterTypes = []string{
+		blockatlas.TxTransfer,
+		blockatlas.TxNativeTokenTransfer,
+		blockatlas.TxTokenTransfer,
+		blockatlas.TxCollectibleTransfer,
+		blockatlas.TxTokenSwap,
+		blockatlas.TxContractCall,
+		blockatlas.TxDelegate,
+		blockatlas.TxUndelegate,
+		blockatlas.TxClaimRewards,
+		blockatlas.TxVote,
+	}
+	filterStatuses = []string{
+		blockatlas.StatusCompleted,
+		blockatlas.StatusFailed,
+		blockatlas.StatusPending,
+	}
+	filterDirections = []string{
+		blockatlas.DirectionOutgoing,
+		blockatlas.DirectionIncoming,
+		blockatlas.DirectionSelf,
+	}
+)
+
+// getTxFilter reads the filters of a transaction route from the query:
+//  - type, status: comma separated lists
+//  - direction: relative to the requested address
+//  - since, until: range of dates (Unix timestamps)
+//  - from_block, to_block: range of block heights
+//  - min_value: minimum value in the smallest unit
+// Returns false if a filter is invalid.
+func getTxFilter(c *gin.Context, p blockatlas.Platform, address string) (*blockatlas.TxFilter, bool) {
+	f, err := readTxFilter(c, address)
+	if !handleError(c, p, err) {
+		return nil, false
+	}
+	f.Canonical = canonicalizer(p)
+	return f, true
+}
+
+// readTxFilter reads the filters of a request for the transactions of address
+func readTxFilter(c *gin.Context, address string) (f *blockatlas.TxFilter, err error) {
+	f = &blockatlas.TxFilter{Address: address}
+	if f.Types, err = queryList(c, "type", filterTypes); err != nil {
+		return nil, err
+	}
+	if f.Statuses, err = queryList(c, "status", filterStatuses); err != nil {
+		return nil, err
+	}
+	f.Direction = c.Query("direction")
+	if f.Direction != "" && !util.Contains(filterDirections, f.Direction) {
+		return nil, invalidFilter("direction", fmt.Sprintf("unknown value %q", f.Direction))
+	}
+
+	if f.Since, err = queryInt(c, "since"); err != nil {
+		return nil, err
+	}
+	if f.Until, err = queryInt(c, "until"); err != nil {
+		return nil, err
+	}
+	if f.Until != 0 && f.Since > f.Until {
+		return nil, invalidFilter("since", "after until")
+	}
+
+	fromBlock, err := queryInt(c, "from_block")
+	if err != nil {
+		return nil, err
+	}
+	toBlock, err := queryInt(c, "to_block")
+	if err != nil {
+		return nil, err
+	}
+	if toBlock != 0 && fromBlock > toBlock {
+		return nil, invalidFilter("from_block", "after to_block")
+	}
+	f.FromBlock, f.ToBlock = uint64(fromBlock), uint64(toBlock)
+
+	if minValue := c.Query("min_value"); minValue != "" {
+		if f.MinValue, err = blockatlas.ParseAmount(minValue, 0); err != nil {
+			return nil, invalidFilter("min_value", "not an integer amount")
+		}
+	}
+	return f, nil
+}
+
+// queryList reads a comma separated list of allowed values
+func queryList(c *gin.Context, name string, allowed []string) ([]string, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	list := strings.Split(value, ",")
+	for _, item := range list {
+		if !util.Contains(allowed, item) {
+			return nil, invalidFilter(name, fmt.Sprintf("unknown value %q", item))
+		}
+	}
+	return list, nil
+}
+
+// queryInt reads a non-negative integer, 0 if it is not set
+func queryInt(c *gin.Context, name string) (int64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, invalidFilter(name, "not a non-negative integer")
+	}
+	return n, nil
+}
+
+func invalidFilter(name string, reason string) error {
+	return &blockatlas.Error{
+		Kind:    blockatlas.KindInvalidRequest,
+		Message: fmt.Sprintf("invalid %s: %s", name, reason),
+	}
+}
+
+// canonicalizer converts addresses into the canonical form of a platform.
+// Addresses the platform rejects are kept as is.
+func canonicalizer(p blockatlas.Platform) func(string) string {
+	if _, ok := p.(blockatlas.AddressValidator); !ok {
+		return nil
+	}
+	return func(address string) string {
+		return blockatlas.CanonicalAddress(p, address)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReadTxFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	read := func(query string) (*blockatlas.TxFilter, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/?"+query, nil)
+		return readTxFilter(c, "addr")
+	}
+
+	f, err := read("type=transfer,token_transfer&status=completed&direction=incoming" +
+		"&since=10&until=20&from_block=1&to_block=2&min_value=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &blockatlas.TxFilter{
+		Types:     []string{blockatlas.TxTransfer, blockatlas.TxTokenTransfer},
+		Statuses:  []string{blockatlas.StatusCompleted},
+		Direction: blockatlas.DirectionIncoming,
+		Address:   "addr",
+		Since:     10,
+		Until:     20,
+		FromBlock: 1,
+		ToBlock:   2,
+		MinValue:  "100",
+	}
+	if !reflect.DeepEqual(f, expected) {
+		t.Errorf("expected %+v, got %+v", expected, f)
+	}
+
+	for _, query := range []string{
+		"type=unknown",
+		"status=done",
+		"direction=incoming,outgoing",
+		"since=-1",
+		"since=20&until=10",
+		"from_block=x",
+		"from_block=5&to_block=1",
+		"min_value=1.5",
+	} {
+		if _, err := read(query); err == nil {
+			t.Errorf("expected error for %s", query)
+		}
+	}
+}
@@ -1,62 +1,337 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/util"
 	"net/http"
+	"strings"
+	"sync"
 )
 
-func makeTxRoute(router gin.IRouter, api blockatlas.TxAPI) {
+func makeTxRoute(router gin.IRouter, p blockatlas.Platform, api blockatlas.TxContextAPI) {
 	router.GET("/:address/txs", func(c *gin.Context) {
-		address := c.Param("address")
-		if address == "" {
-			c.String(http.StatusBadRequest, "bad request")
+		address, ok := getAddress(c, p)
+		if !ok {
+			return
+		}
+		filter, ok := getTxFilter(c, p, address)
+		if !ok {
 			return
 		}
 
-		page, err := api.GetTxsByAddress(address)
-		if !handleError(c, err) {
+		page, err := addressTxs(c.Request.Context(), p, api, address)
+		if !handleError(c, p, err) {
 			return
 		}
 
+		page = page.Filter(filter)
+		page.SetDirection(address, filter.Canonical)
 		page.Sort()
-		c.JSON(http.StatusOK, &page)
+		renderJSON(c, &page)
+	})
+}
+
+func makePagedTxRoute(router gin.IRouter, p blockatlas.Platform, api blockatlas.PagedTxContextAPI) {
+	router.GET("/:address/txs", func(c *gin.Context) {
+		address, ok := getAddress(c, p)
+		if !ok {
+			return
+		}
+		filter, ok := getTxFilter(c, p, address)
+		if !ok {
+			return
+		}
+
+		cursor := c.Query("cursor")
+		key := flightKey(p.Coin().Handle, "txs", address, cursor)
+		v, err := flights.do(c.Request.Context(), key, func(ctx context.Context) (interface{}, error) {
+			txs, next, err := api.GetTxPageByAddressContext(ctx, address, cursor)
+			return blockatlas.TxCursorPage{Txs: txs, Next: next}, err
+		})
+		if !handleError(c, p, err) {
+			return
+		}
+		shared := v.(blockatlas.TxCursorPage)
+		txs, next := append(blockatlas.TxPage(nil), shared.Txs...), shared.Next
+
+		// Pending transactions are newer than any page
+		if c.Query("cursor") == "" {
+			txs = mergePending(c.Request.Context(), p, address, txs)
+		}
+
+		txs = txs.Filter(filter)
+		txs.SetDirection(address, filter.Canonical)
+		txs.Sort()
+		page := blockatlas.TxCursorPage{Txs: txs, Next: next}
+		renderJSON(c, &page)
 	})
 }
 
-func makeTokenTxRoute(router gin.IRouter, api blockatlas.TokenTxAPI) {
+// batchRequest is the body of batch transaction lookups
+type batchRequest struct {
+	Addresses []string `json:"addresses"`
+	Token     string   `json:"token"`
+}
+
+// makeBatchTxRoute serves the merged transactions of multiple addresses.
+// tokenAPI is nil if the platform has no token transactions.
+func makeBatchTxRoute(router gin.IRouter, p blockatlas.Platform, api blockatlas.TxContextAPI, tokenAPI blockatlas.TokenTxContextAPI) {
+	router.POST("/txs", func(c *gin.Context) {
+		var req batchRequest
+		if err := c.ShouldBindJSON(&req); err != nil || len(req.Addresses) == 0 {
+			handleError(c, p, blockatlas.ErrInvalidRequest)
+			return
+		}
+		if max := viper.GetInt("api.batch_max_addresses"); len(req.Addresses) > max {
+			handleError(c, p, &blockatlas.Error{
+				Kind:    blockatlas.KindInvalidRequest,
+				Message: fmt.Sprintf("at most %d addresses per batch", max),
+			})
+			return
+		}
+		if req.Token != "" && tokenAPI == nil {
+			handleError(c, p, &blockatlas.Error{
+				Kind:    blockatlas.KindInvalidRequest,
+				Message: "token transactions not supported",
+			})
+			return
+		}
+
+		handle := p.Coin().Handle
+		lookup := func(ctx context.Context, address string) (blockatlas.TxPage, error) {
+			if req.Token != "" {
+				key := flightKey(handle, "token_txs", address, req.Token)
+				return coalesceTxs(ctx, key, func(ctx context.Context) (blockatlas.TxPage, error) {
+					return tokenAPI.GetTokenTxsByAddressContext(ctx, address, req.Token)
+				})
+			}
+			return addressTxs(ctx, p, api, address)
+		}
+
+		// Look up the addresses with bounded concurrency
+		ctx := c.Request.Context()
+		pages := make([]blockatlas.TxPage, len(req.Addresses))
+		errs := make([]error, len(req.Addresses))
+		sem := util.NewSemaphore(viper.GetInt("api.batch_concurrency"))
+		var wg sync.WaitGroup
+		wg.Add(len(req.Addresses))
+		for i, address := range req.Addresses {
+			go func(i int, address string) {
+				defer wg.Done()
+				sem.Acquire()
+				defer sem.Release()
+				canonical, err := canonicalAddress(p.Coin().ID, address)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				pages[i], errs[i] = lookup(ctx, canonical)
+			}(i, address)
+		}
+		wg.Wait()
+
+		var batch blockatlas.TxBatchPage
+		for i, address := range req.Addresses {
+			if errs[i] != nil {
+				batch.AddError(address, blockatlas.ToError(errs[i]).WithPlatform(handle))
+				continue
+			}
+			batch.Add(pages[i])
+		}
+		batch.Txs.Sort()
+		renderJSON(c, &batch)
+	})
+}
+
+// addressTxs returns the transactions of an address including pending ones
+func addressTxs(ctx context.Context, p blockatlas.Platform, api blockatlas.TxContextAPI, address string) (blockatlas.TxPage, error) {
+	key := flightKey(p.Coin().Handle, "txs", address)
+	page, err := coalesceTxs(ctx, key, func(ctx context.Context) (blockatlas.TxPage, error) {
+		return api.GetTxsByAddressContext(ctx, address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergePending(ctx, p, address, page), nil
+}
+
+// mergePending adds the pending transactions of an address
+// if the platform supports them and they are not confirmed yet
+func mergePending(ctx context.Context, api blockatlas.Platform, address string, page blockatlas.TxPage) blockatlas.TxPage {
+	pendingAPI, ok := blockatlas.AsPendingTxContextAPI(api)
+	if !ok {
+		return page
+	}
+
+	pending, err := pendingAPI.GetPendingTxsByAddressContext(ctx, address)
+	if err != nil {
+		// Pending transactions are optional, keep the confirmed ones
+		logrus.WithError(err).WithField("platform", api.Coin().Handle).
+			Warn("Failed to get pending transactions")
+		return page
+	}
+
+	page.AddPending(pending)
+	return page
+}
+
+func makeTokenTxRoute(router gin.IRouter, p blockatlas.Platform, api blockatlas.TokenTxContextAPI) {
 	router.GET("/:address/token/:token/txs", func(c *gin.Context) {
-		address := c.Param("address")
+		address, ok := getAddress(c, p)
+		if !ok {
+			return
+		}
 		token := c.Param("token")
-		if address == "" || token == "" {
-			c.String(http.StatusBadRequest, "bad request")
+		if token == "" {
+			handleError(c, p, blockatlas.ErrInvalidRequest)
+			return
+		}
+		filter, ok := getTxFilter(c, p, address)
+		if !ok {
 			return
 		}
 
-		page, err := api.GetTokenTxsByAddress(address, token)
-		if !handleError(c, err) {
+		key := flightKey(p.Coin().Handle, "token_txs", address, token)
+		page, err := coalesceTxs(c.Request.Context(), key, func(ctx context.Context) (blockatlas.TxPage, error) {
+			return api.GetTokenTxsByAddressContext(ctx, address, token)
+		})
+		if !handleError(c, p, err) {
 			return
 		}
 
+		page = page.Filter(filter)
+		page.SetDirection(address, filter.Canonical)
 		page.Sort()
-		c.JSON(http.StatusOK, &page)
-	})
-}
-
-func handleError(c *gin.Context, err error) bool {
-	switch {
-	case err == blockatlas.ErrInvalidAddr:
-		c.String(http.StatusBadRequest, "Invalid address")
-		return false
-	case err == blockatlas.ErrNotFound:
-		c.String(http.StatusNotFound, "No such address")
-		return false
-	case err == blockatlas.ErrSourceConn:
-		c.String(http.StatusServiceUnavailable, "Lost connection to blockchain")
-		return false
-	case err != nil:
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
-		return false
-	}
-	return true
+		renderJSON(c, &page)
+	})
+}
+
+func makeTxByIDRoute(router gin.IRouter, api blockatlas.TxByIDContextAPI) {
+	router.GET("/tx/:id", func(c *gin.Context) {
+		id := c.Param("id")
+		if id == "" {
+			handleError(c, api, blockatlas.ErrInvalidRequest)
+			return
+		}
+
+		tx, err := api.GetTxContext(c.Request.Context(), id)
+		if !handleError(c, api, err) {
+			return
+		}
+
+		renderJSON(c, tx)
+	})
+}
+
+func makeBalanceRoute(router gin.IRouter, api blockatlas.BalanceContextAPI) {
+	router.GET("/:address/balance", func(c *gin.Context) {
+		address, ok := getAddress(c, api)
+		if !ok {
+			return
+		}
+
+		balance, err := addressBalance(c.Request.Context(), api, address)
+		if !handleError(c, api, err) {
+			return
+		}
+		renderJSON(c, &balance)
+	})
+}
+
+// addressBalance returns the balance of an address
+func addressBalance(ctx context.Context, api blockatlas.BalanceContextAPI, address string) (blockatlas.Balance, error) {
+	key := flightKey(api.Coin().Handle, "balance", address)
+	v, err := flights.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return api.GetBalanceContext(ctx, address)
+	})
+	if err != nil {
+		return blockatlas.Balance{}, err
+	}
+	return blockatlas.Balance{
+		Balance:  v.(blockatlas.Amount),
+		Decimals: api.Coin().Decimals,
+	}, nil
+}
+
+func makeTokenListRoute(router gin.IRouter, api blockatlas.TokenListContextAPI) {
+	router.GET("/:address/tokens", func(c *gin.Context) {
+		address, ok := getAddress(c, api)
+		if !ok {
+			return
+		}
+
+		key := flightKey(api.Coin().Handle, "tokens", address)
+		v, err := flights.do(c.Request.Context(), key, func(ctx context.Context) (interface{}, error) {
+			return api.GetTokenListByAddressContext(ctx, address)
+		})
+		if !handleError(c, api, err) {
+			return
+		}
+		tokens := v.(blockatlas.TokenPage)
+
+		renderJSON(c, &tokens)
+	})
+}
+
+// getAddress reads the address parameter of the request
+// and converts it into the canonical form of the platform.
+// Returns false if the address is invalid.
+func getAddress(c *gin.Context, api blockatlas.Platform) (string, bool) {
+	address := c.Param("address")
+	if address == "" {
+		handleError(c, api, blockatlas.ErrInvalidRequest)
+		return "", false
+	}
+	address, err := blockatlas.ValidateAddress(api, address)
+	if !handleError(c, api, err) {
+		return "", false
+	}
+	return address, true
+}
+
+// handleError renders err as JSON error body.
+// Returns false if the request failed.
+func handleError(c *gin.Context, api blockatlas.Platform, err error) bool {
+	if err == nil {
+		return true
+	}
+	blockatlas.RenderError(c, api.Coin().Handle, err)
+	return false
+}
+
+// renderJSON renders v as JSON with an ETag of the body.
+// Clients sending the ETag in If-None-Match get 304 Not Modified
+// if the body did not change.
+func renderJSON(c *gin.Context, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		blockatlas.RenderError(c, "", err)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%x"`, sum[:16])
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches checks whether an If-None-Match header lists the ETag
+func etagMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
 }
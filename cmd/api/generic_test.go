@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/platform"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchPlatform serves fixed transactions of lowercase addresses
+// and records the number of concurrent lookups
+type batchPlatform struct {
+	txs       map[string]blockatlas.TxPage
+	tokenTxs  map[string]blockatlas.TxPage
+	mu        sync.Mutex
+	running   int
+	maxActive int
+}
+
+func (p *batchPlatform) Init() error     { return nil }
+func (p *batchPlatform) Coin() coin.Coin { return coin.Coins[coin.NIM] }
+
+func (p *batchPlatform) ValidateAddress(address string) (string, error) {
+	if address == "invalid" {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return strings.ToLower(address), nil
+}
+
+func (p *batchPlatform) GetTxsByAddressContext(_ context.Context, address string) (blockatlas.TxPage, error) {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.maxActive {
+		p.maxActive = p.running
+	}
+	p.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+
+	txs, ok := p.txs[address]
+	if !ok {
+		return nil, blockatlas.ErrSourceConn
+	}
+	return txs, nil
+}
+
+func (p *batchPlatform) GetTokenTxsByAddressContext(_ context.Context, address, token string) (blockatlas.TxPage, error) {
+	return p.tokenTxs[address+"/"+token], nil
+}
+
+type batchResponse struct {
+	Docs []struct {
+		ID string `json:"id"`
+	} `json:"docs"`
+	Errors map[string]struct {
+		Kind     string `json:"kind"`
+		Platform string `json:"platform"`
+	} `json:"errors"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func postBatch(t *testing.T, engine *gin.Engine, body string) (int, batchResponse) {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/txs", strings.NewReader(body)))
+	var res batchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid response %s: %v", w.Body, err)
+	}
+	return w.Code, res
+}
+
+func TestBatchTxRoute(t *testing.T) {
+	tx := func(id string, date int64) blockatlas.Tx {
+		return blockatlas.Tx{ID: id, Coin: coin.NIM, Date: date, Meta: blockatlas.Transfer{Value: "1"}}
+	}
+	p := &batchPlatform{
+		txs: map[string]blockatlas.TxPage{
+			"a": {tx("shared", 1), tx("a", 2)},
+			"b": {tx("shared", 1)},
+			"c": {tx("c", 3)},
+			"d": nil,
+			"e": nil,
+		},
+		tokenTxs: map[string]blockatlas.TxPage{
+			"a/token": {tx("token", 4)},
+		},
+	}
+	platform.Platforms = map[string]blockatlas.Platform{p.Coin().Handle: p}
+	defer func() { platform.Platforms = nil }()
+
+	viper.Set("api.batch_max_addresses", 6)
+	viper.Set("api.batch_concurrency", 2)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	makeBatchTxRoute(engine, p, p, p)
+
+	t.Run("merged", func(t *testing.T) {
+		code, res := postBatch(t, engine, `{"addresses": ["A", "b", "invalid", "c", "d", "e"]}`)
+		if code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", code)
+		}
+		// Transactions shared by addresses are listed once
+		var ids []string
+		for _, doc := range res.Docs {
+			ids = append(ids, doc.ID)
+		}
+		if strings.Join(ids, ",") != "c,a,shared" {
+			t.Errorf("unexpected transactions %v", ids)
+		}
+		if e := res.Errors["invalid"]; len(res.Errors) != 1 ||
+			e.Kind != string(blockatlas.KindInvalidAddr) || e.Platform != "nimiq" {
+			t.Errorf("unexpected errors %+v", res.Errors)
+		}
+		if p.maxActive > 2 {
+			t.Errorf("expected at most 2 concurrent lookups, got %d", p.maxActive)
+		}
+	})
+
+	t.Run("token", func(t *testing.T) {
+		code, res := postBatch(t, engine, `{"addresses": ["a", "b"], "token": "token"}`)
+		if code != http.StatusOK || len(res.Docs) != 1 || res.Docs[0].ID != "token" {
+			t.Errorf("unexpected token transactions %d %+v", code, res)
+		}
+	})
+
+	t.Run("too many addresses", func(t *testing.T) {
+		code, res := postBatch(t, engine, `{"addresses": ["a", "b", "c", "d", "e", "f", "g"]}`)
+		if code != http.StatusBadRequest || res.Error.Message != "at most 6 addresses per batch" {
+			t.Errorf("expected status 400 for too many addresses, got %d %+v", code, res)
+		}
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		code, _ := postBatch(t, engine, `{"addresses": []}`)
+		if code != http.StatusBadRequest {
+			t.Errorf("expected status 400 without addresses, got %d", code)
+		}
+	})
+}
+
+func TestBatchTxRoute_TokenUnsupported(t *testing.T) {
+	p := &batchPlatform{txs: map[string]blockatlas.TxPage{"a": nil}}
+	viper.Set("api.batch_max_addresses", 6)
+	viper.Set("api.batch_concurrency", 2)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	makeBatchTxRoute(engine, p, p, nil)
+
+	code, res := postBatch(t, engine, `{"addresses": ["a"], "token": "token"}`)
+	if code != http.StatusBadRequest || res.Error.Message != "token transactions not supported" {
+		t.Errorf("expected status 400 for token transactions, got %d %+v", code, res)
+	}
+}
+
+func TestRenderJSON_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", func(c *gin.Context) {
+		renderJSON(c, map[string]string{"id": "1"})
+	})
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Body.String() != `{"id":"1"}` {
+		t.Fatalf("unexpected response %d %q %s", w.Code, etag, w.Body)
+	}
+
+	tests := map[string]int{
+		etag:                          http.StatusNotModified,
+		"W/" + etag:                   http.StatusNotModified,
+		`"other", ` + etag:            http.StatusNotModified,
+		`"other",W/` + etag + `, "x"`: http.StatusNotModified,
+		"*":                           http.StatusNotModified,
+		`"other"`:                     http.StatusOK,
+		strings.Trim(etag, `"`):       http.StatusOK,
+	}
+	for header, code := range tests {
+		w := get(header)
+		if w.Code != code {
+			t.Errorf("If-None-Match %s: expected status %d, got %d", header, code, w.Code)
+		}
+		if code == http.StatusNotModified && w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: expected no body, got %s", header, w.Body)
+		}
+		if w.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: expected ETag %s, got %s", header, etag, w.Header().Get("ETag"))
+		}
+	}
+}
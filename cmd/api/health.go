@@ -0,0 +1,120 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/health"
+	observerStorage "github.com/trustwallet/blockatlas/observer/storage"
+	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/upstream"
+	"net/http"
+	"sort"
+)
+
+// prober probes the loaded platforms in the background
+var prober *health.Prober
+
+func setupHealthAPI(router gin.IRouter) {
+	router.GET("/live", getLive)
+	router.GET("/ready", getReady)
+	router.GET("/platforms", getPlatformHealth)
+}
+
+// getLive reports that the process is serving requests
+func getLive(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// getReady reports whether the API can serve requests:
+// Platforms are loaded and Redis is reachable if the observer is enabled
+func getReady(c *gin.Context) {
+	checks := make(map[string]string)
+	ready := true
+
+	if len(platform.Platforms) == 0 {
+		checks["platforms"] = "no platforms loaded"
+		ready = false
+	} else {
+		checks["platforms"] = "ok"
+	}
+	if observerStorage.App != nil {
+		if err := observerStorage.Ping(); err != nil {
+			checks["redis"] = err.Error()
+			ready = false
+		} else {
+			checks["redis"] = "ok"
+		}
+	}
+
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{"ready": ready, "checks": checks})
+}
+
+// platformHealth is the health of the source API of a platform
+type platformHealth struct {
+	Handle  string                  `json:"handle"`
+	Healthy bool                    `json:"healthy"`
+	Breaker *upstream.BreakerStatus `json:"breaker,omitempty"`
+	Probe   *health.Result          `json:"probe,omitempty"`
+}
+
+// getPlatformHealth returns the last probe and the circuit breaker
+// state of all platforms. Probes run in the background, the source
+// APIs are never called by this route.
+// Fails with HTTP 503 if all platforms are unhealthy.
+func getPlatformHealth(c *gin.Context) {
+	breakers := make(map[string]upstream.BreakerStatus)
+	for _, s := range upstream.Breakers() {
+		breakers[s.Handle] = s
+	}
+
+	var resp struct {
+		Platforms []platformHealth `json:"platforms"`
+	}
+	resp.Platforms = make([]platformHealth, 0, len(platform.Platforms))
+	healthy := 0
+	for _, handle := range sortedHandles() {
+		h := platformHealth{Handle: handle, Healthy: true}
+		if s, ok := breakers[handle]; ok {
+			h.Breaker = &s
+			h.Healthy = s.State != upstream.StateOpen
+		}
+		if prober != nil {
+			if r, ok := prober.Result(handle); ok {
+				h.Probe = &r
+				h.Healthy = h.Healthy && r.Healthy
+			}
+		}
+		if h.Healthy {
+			healthy++
+		}
+		resp.Platforms = append(resp.Platforms, h)
+	}
+
+	status := http.StatusOK
+	if len(resp.Platforms) > 0 && healthy == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, &resp)
+}
+
+// sortedHandles returns the handles of the loaded platforms in order
+func sortedHandles() []string {
+	handles := make([]string, 0, len(platform.Platforms))
+	for handle := range platform.Platforms {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	return handles
+}
+
+// breakerStates returns the circuit breaker state by platform handle
+func breakerStates() map[string]upstream.BreakerState {
+	states := make(map[string]upstream.BreakerState)
+	for _, s := range upstream.Breakers() {
+		states[s.Handle] = s.State
+	}
+	return states
+}
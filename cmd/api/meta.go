@@ -2,7 +2,11 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
+	"path"
+	"sort"
 )
 
 func getRoot(c *gin.Context) {
@@ -13,18 +17,129 @@ Don't know how you landed here?
 Visit https://trustwallet.com to get back to the main page.
 
 If you know what you're doing:
- - Visit /v1/ to list platforms
+ - Visit /v2/ to list platforms
  - Source: https://github.com/trustwallet/blockatlas
  - Any questions? https://t.me/walletcore
 `)
 }
 
-func getEnabledEndpoints(c *gin.Context) {
+// Capabilities of a platform
+const (
+	capTx                = "tx"
+	capBatchTx           = "batch_tx"
+	capPagedTx           = "paged_tx"
+	capPendingTx         = "pending_tx"
+	capTxByID            = "tx_by_id"
+	capTokenTx           = "token_tx"
+	capTokenList         = "token_list"
+	capBalance           = "balance"
+	capBlock             = "block"
+	capCustom            = "custom"
+	capAddressValidation = "address_validation"
+)
+
+// platformInfos contains the capabilities of all loaded platforms by handle
+var platformInfos = make(map[string]*platformInfo)
+
+// platformInfo describes a platform and its capabilities
+type platformInfo struct {
+	Handle       string        `json:"handle"`
+	ID           uint          `json:"id"`
+	Symbol       string        `json:"symbol"`
+	Name         string        `json:"name"`
+	Decimals     uint          `json:"decimals"`
+	BlockTime    int           `json:"block_time,omitempty"`
+	Capabilities []*capability `json:"capabilities"`
+	// State of the circuit breaker of the source API
+	Health upstream.BreakerState `json:"health,omitempty"`
+}
+
+// capability is an API implemented by a platform
+// and the templates of the routes serving it
+type capability struct {
+	Name   string   `json:"name"`
+	Routes []string `json:"routes,omitempty"`
+}
+
+func newPlatformInfo(p blockatlas.Platform) *platformInfo {
+	c := p.Coin()
+	return &platformInfo{
+		Handle:       c.Handle,
+		ID:           c.ID,
+		Symbol:       c.Symbol,
+		Name:         c.Title,
+		Decimals:     c.Decimals,
+		BlockTime:    c.BlockTime,
+		Capabilities: []*capability{},
+	}
+}
+
+func (info *platformInfo) add(name string) *capability {
+	c := &capability{Name: name}
+	info.Capabilities = append(info.Capabilities, c)
+	return c
+}
+
+// record adds a capability and returns a router
+// recording the routes registered for it
+func (info *platformInfo) record(name string, router gin.IRouter) gin.IRouter {
+	c := info.add(name)
+	return &routeRecorder{
+		IRouter: router,
+		prefix:  path.Join("/v2", info.Handle),
+		routes:  &c.Routes,
+	}
+}
+
+// routeRecorder is a router recording the templates of its routes.
+// Routes of methods other than GET are prefixed with their method.
+type routeRecorder struct {
+	gin.IRouter
+	prefix string
+	routes *[]string
+}
+
+func (r *routeRecorder) GET(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	*r.routes = append(*r.routes, path.Join(r.prefix, relativePath))
+	return r.IRouter.GET(relativePath, handlers...)
+}
+
+func (r *routeRecorder) POST(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	*r.routes = append(*r.routes, "POST "+path.Join(r.prefix, relativePath))
+	return r.IRouter.POST(relativePath, handlers...)
+}
+
+func getCapabilities(c *gin.Context) {
 	var resp struct {
-		Endpoints []string `json:"endpoints,omitempty"`
+		// Handles of all platforms, kept for older clients
+		Endpoints []string        `json:"endpoints,omitempty"`
+		Platforms []*platformInfo `json:"platforms"`
 	}
-	for handle := range routers {
+	resp.Platforms = make([]*platformInfo, 0, len(platformInfos))
+	states := breakerStates()
+	for handle, info := range platformInfos {
 		resp.Endpoints = append(resp.Endpoints, handle)
+		resp.Platforms = append(resp.Platforms, info.withHealth(states[handle]))
 	}
+	sort.Strings(resp.Endpoints)
+	sort.Slice(resp.Platforms, func(i, j int) bool {
+		return resp.Platforms[i].ID < resp.Platforms[j].ID
+	})
 	c.JSON(http.StatusOK, &resp)
 }
+
+func getPlatformCapabilities(c *gin.Context) {
+	info, ok := platformInfos[c.Param("handle")]
+	if !ok {
+		blockatlas.RenderError(c, "", blockatlas.ErrNotFound)
+		return
+	}
+	c.JSON(http.StatusOK, info.withHealth(breakerStates()[info.Handle]))
+}
+
+// withHealth returns a copy of the info with the given breaker state
+func (info *platformInfo) withHealth(state upstream.BreakerState) *platformInfo {
+	c := *info
+	c.Health = state
+	return &c
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/observer"
 	observerStorage "github.com/trustwallet/blockatlas/observer/storage"
+	"github.com/trustwallet/blockatlas/platform"
 	"net/http"
 	"strconv"
 )
@@ -21,7 +23,10 @@ func requireAuth(c *gin.Context) {
 	if c.GetHeader("Authorization") == auth {
 		c.Next()
 	} else {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		blockatlas.RenderError(c, "", &blockatlas.Error{
+			Kind:    blockatlas.KindUnauthorized,
+			Message: "invalid authorization",
+		})
 	}
 }
 
@@ -30,7 +35,8 @@ func addCall(c *gin.Context) {
 		Subscriptions map[string][]string `json:"subscriptions"`
 		Webhook string `json:"webhook"`
 	}
-	if c.BindJSON(&req) != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
+		blockatlas.RenderError(c, "", blockatlas.ErrInvalidRequest)
 		return
 	}
 
@@ -46,6 +52,11 @@ func addCall(c *gin.Context) {
 			continue
 		}
 		for _, addr := range perCoin {
+			addr, err := canonicalAddress(uint(coin), addr)
+			if err != nil {
+				blockatlas.RenderError(c, "", err)
+				return
+			}
 			subs = append(subs, observer.Subscription{
 				Coin:    uint(coin),
 				Address: addr,
@@ -56,7 +67,7 @@ func addCall(c *gin.Context) {
 
 	err := observerStorage.App.Add(subs)
 	if err != nil {
-		_ = c.Error(err)
+		blockatlas.RenderError(c, "", err)
 		return
 	}
 
@@ -65,7 +76,8 @@ func addCall(c *gin.Context) {
 
 func deleteCall(c *gin.Context) {
 	var req map[string][]string
-	if c.BindJSON(&req) != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
+		blockatlas.RenderError(c, "", blockatlas.ErrInvalidRequest)
 		return
 	}
 
@@ -81,6 +93,11 @@ func deleteCall(c *gin.Context) {
 			continue
 		}
 		for _, addr := range perCoin {
+			addr, err := canonicalAddress(uint(coin), addr)
+			if err != nil {
+				blockatlas.RenderError(c, "", err)
+				return
+			}
 			subs = append(subs, observer.Subscription{
 				Coin:    uint(coin),
 				Address: addr,
@@ -90,9 +107,23 @@ func deleteCall(c *gin.Context) {
 
 	err := observerStorage.App.Delete(subs)
 	if err != nil {
-		_ = c.Error(err)
+		blockatlas.RenderError(c, "", err)
 		return
 	}
 
 	c.String(http.StatusOK, "Deleted")
 }
+
+// canonicalAddress validates the address of a subscription and
+// converts it into the canonical form used as storage key.
+// Addresses of coins without validator are kept as is.
+func canonicalAddress(coin uint, address string) (string, error) {
+	p := platform.ByCoin(coin)
+	canonical, err := blockatlas.ValidateAddress(p, address)
+	if err != nil {
+		e := blockatlas.ToError(err).WithPlatform(p.Coin().Handle)
+		e.Message = fmt.Sprintf("invalid address: %s", address)
+		return "", e
+	}
+	return canonical, nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/util"
+	"strconv"
+	"sync"
+)
+
+// portfolioSource looks up the activity of the addresses of a coin.
+// Either API is nil if the platform does not support it.
+type portfolioSource struct {
+	platform   blockatlas.Platform
+	txAPI      blockatlas.TxContextAPI
+	balanceAPI blockatlas.BalanceContextAPI
+}
+
+// portfolioSources by coin ID
+var portfolioSources = make(map[uint]*portfolioSource)
+
+func setupPortfolioAPI(router gin.IRouter) {
+	timeout := viper.GetDuration("api.request_timeout")
+	router.POST("/portfolio", withDeadline(timeout), getPortfolio)
+}
+
+// getPortfolio returns the transactions and balances
+// of addresses across coins as one timeline
+func getPortfolio(c *gin.Context) {
+	var req map[string][]string
+	if err := c.ShouldBindJSON(&req); err != nil || len(req) == 0 {
+		blockatlas.RenderError(c, "", blockatlas.ErrInvalidRequest)
+		return
+	}
+
+	coins := make(map[uint][]string, len(req))
+	count := 0
+	for coinStr, addresses := range req {
+		coin, err := strconv.ParseUint(coinStr, 10, 32)
+		if err != nil {
+			blockatlas.RenderError(c, "", &blockatlas.Error{
+				Kind:    blockatlas.KindInvalidRequest,
+				Message: fmt.Sprintf("invalid coin %q", coinStr),
+			})
+			return
+		}
+		coins[uint(coin)] = append(coins[uint(coin)], addresses...)
+		count += len(addresses)
+	}
+	if max := viper.GetInt("api.batch_max_addresses"); count > max {
+		blockatlas.RenderError(c, "", &blockatlas.Error{
+			Kind:    blockatlas.KindInvalidRequest,
+			Message: fmt.Sprintf("at most %d addresses per portfolio", max),
+		})
+		return
+	}
+
+	var portfolio blockatlas.Portfolio
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := util.NewSemaphore(viper.GetInt("api.batch_concurrency"))
+	for coin, addresses := range coins {
+		source := portfolioSources[coin]
+		for _, address := range addresses {
+			if source == nil {
+				mu.Lock()
+				portfolio.AddError(coin, address, noPortfolioSource(coin))
+				mu.Unlock()
+				continue
+			}
+			wg.Add(1)
+			go func(coin uint, address string) {
+				defer wg.Done()
+				sem.Acquire()
+				defer sem.Release()
+				txs, balance, err := source.lookup(c.Request.Context(), address)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					handle := source.platform.Coin().Handle
+					portfolio.AddError(coin, address, blockatlas.ToError(err).WithPlatform(handle))
+				}
+				portfolio.Add(txs)
+				if balance != nil {
+					portfolio.AddBalance(coin, address, *balance)
+				}
+			}(coin, address)
+		}
+	}
+	wg.Wait()
+
+	portfolio.Txs.Sort()
+	renderJSON(c, &portfolio)
+}
+
+// noPortfolioSource returns the error of a coin without portfolio source
+func noPortfolioSource(coin uint) error {
+	if p := platform.ByCoin(coin); p != nil {
+		return blockatlas.ErrUnsupported.WithPlatform(p.Coin().Handle)
+	}
+	return &blockatlas.Error{
+		Kind:    blockatlas.KindNotFound,
+		Message: "unknown coin",
+	}
+}
+
+// lookup returns the transactions and the balance of an address.
+// Lookups that succeeded are returned along with the error of the failed one.
+func (s *portfolioSource) lookup(ctx context.Context, address string) (blockatlas.TxPage, *blockatlas.Balance, error) {
+	address, err := canonicalAddress(s.platform.Coin().ID, address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var txs blockatlas.TxPage
+	var txErr error
+	if s.txAPI != nil {
+		txs, txErr = addressTxs(ctx, s.platform, s.txAPI, address)
+	}
+	if s.balanceAPI == nil {
+		return txs, nil, txErr
+	}
+	balance, err := addressBalance(ctx, s.balanceAPI, address)
+	if err != nil {
+		if txErr != nil {
+			return txs, nil, txErr
+		}
+		return txs, nil, err
+	}
+	return txs, &balance, txErr
+}
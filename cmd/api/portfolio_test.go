@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/platform"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// portfolioPlatform serves fixed transactions and balances
+type portfolioPlatform struct {
+	coin coin.Coin
+	txs  map[string]blockatlas.TxPage
+}
+
+func (p *portfolioPlatform) Init() error     { return nil }
+func (p *portfolioPlatform) Coin() coin.Coin { return p.coin }
+
+func (p *portfolioPlatform) GetTxsByAddressContext(_ context.Context, address string) (blockatlas.TxPage, error) {
+	txs, ok := p.txs[address]
+	if !ok {
+		return nil, blockatlas.ErrSourceConn
+	}
+	return txs, nil
+}
+
+func (p *portfolioPlatform) GetBalanceContext(_ context.Context, address string) (blockatlas.Amount, error) {
+	return "100", nil
+}
+
+func TestGetPortfolio(t *testing.T) {
+	tx := func(c coin.Coin, id string, date int64) blockatlas.Tx {
+		return blockatlas.Tx{ID: id, Coin: c.ID, Date: date, Meta: blockatlas.Transfer{Value: "1"}}
+	}
+	nim := &portfolioPlatform{coin: coin.Coins[coin.NIM], txs: map[string]blockatlas.TxPage{
+		"a": {tx(coin.Coins[coin.NIM], "shared", 1), tx(coin.Coins[coin.NIM], "nim", 3)},
+		"b": {tx(coin.Coins[coin.NIM], "shared", 1)},
+	}}
+	xtz := &portfolioPlatform{coin: coin.Coins[coin.XTZ], txs: map[string]blockatlas.TxPage{
+		"c": {tx(coin.Coins[coin.XTZ], "shared", 2)},
+	}}
+	portfolioSources[nim.coin.ID] = &portfolioSource{platform: nim, txAPI: nim, balanceAPI: nim}
+	portfolioSources[xtz.coin.ID] = &portfolioSource{platform: xtz, txAPI: xtz}
+	defer func() {
+		delete(portfolioSources, nim.coin.ID)
+		delete(portfolioSources, xtz.coin.ID)
+	}()
+	// Registered, but without portfolio source
+	icx := &portfolioPlatform{coin: coin.Coins[coin.ICX]}
+	platform.Platforms = map[string]blockatlas.Platform{icx.coin.Handle: icx}
+	defer func() { platform.Platforms = nil }()
+
+	viper.Set("api.batch_max_addresses", 10)
+	viper.Set("api.batch_concurrency", 2)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	setupPortfolioAPI(engine)
+	body := []byte(`{"242": ["a", "b"], "1729": ["c", "failing"], "99999": ["d"], "74": ["e"]}`)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/portfolio", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+
+	var res struct {
+		Docs []struct {
+			ID   string `json:"id"`
+			Coin uint   `json:"coin"`
+		} `json:"docs"`
+		Balances map[string]map[string]blockatlas.Balance `json:"balances"`
+		Errors   map[string]map[string]struct {
+			Kind     string `json:"kind"`
+			Platform string `json:"platform"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	// Transactions shared by addresses of a coin are merged, not across coins
+	var got []string
+	for _, tx := range res.Docs {
+		got = append(got, tx.ID)
+	}
+	if len(res.Docs) != 3 || res.Docs[0].ID != "nim" || res.Docs[1].Coin != coin.XTZ {
+		t.Errorf("unexpected timeline %v", got)
+	}
+	if len(res.Balances["242"]) != 2 || res.Balances["242"]["a"].Balance != "100" {
+		t.Errorf("unexpected balances %v", res.Balances)
+	}
+	if _, ok := res.Balances["1729"]; ok {
+		t.Error("expected no balances of a platform without balance lookups")
+	}
+	if e := res.Errors["1729"]["failing"]; e.Kind != string(blockatlas.KindUnavailable) || e.Platform != "tezos" {
+		t.Errorf("unexpected error of failing address %+v", e)
+	}
+	if e := res.Errors["99999"]["d"]; e.Kind != string(blockatlas.KindNotFound) {
+		t.Errorf("unexpected error of unknown coin %+v", e)
+	}
+	if e := res.Errors["74"]["e"]; e.Kind != string(blockatlas.KindUnsupported) || e.Platform != "icon" {
+		t.Errorf("unexpected error of unsupported coin %+v", e)
+	}
+}
+
+func TestGetPortfolio_InvalidCoin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	setupPortfolioAPI(engine)
+	body := []byte(`{"nimiq": ["a"]}`)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/portfolio", bytes.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
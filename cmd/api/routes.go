@@ -1,36 +1,109 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/cache"
+	observerStorage "github.com/trustwallet/blockatlas/observer/storage"
 	"github.com/trustwallet/blockatlas/platform"
+	"time"
 )
 
 var routers = make(map[string]gin.IRouter)
 
 func loadPlatforms(root gin.IRouter) {
 	v2 := root.Group("/v2")
+	store := loadCacheStore()
 	for handle, p := range platform.Platforms {
-		loadPlatform(v2, handle, p)
+		loadPlatform(v2, handle, p, store)
 	}
 
 	logrus.WithField("routes", len(routers)).
 		Info("Routes set up")
 
-	v2.GET("/", getEnabledEndpoints)
+	setupPortfolioAPI(v2)
+	v2.GET("/", getCapabilities)
+	v2.GET("/:handle", getPlatformCapabilities)
 }
 
-func loadPlatform(g gin.IRouter, handle string, p blockatlas.Platform) {
+// loadPlatform registers the routes of a platform.
+// Transaction lookups are cached if the store is not nil.
+func loadPlatform(g gin.IRouter, handle string, p blockatlas.Platform, store cache.Store) {
+	info := newPlatformInfo(p)
+	defer func() { platformInfos[handle] = info }()
+
+	// router records the routes of a capability
+	router := func(capability string) gin.IRouter {
+		return info.record(capability, getRouter(g, handle))
+	}
+
 	if customAPI, ok := p.(blockatlas.CustomAPI); ok {
-		customAPI.RegisterRoutes(getRouter(g, handle))
+		customAPI.RegisterRoutes(router(capCustom))
+	}
+	var c *cache.Cache
+	if store != nil {
+		c = cache.New(store, p.Coin())
+		if c.TTL <= 0 {
+			c = nil
+		}
+	}
+	// Lookups of the batch and portfolio routes
+	var batchAPI blockatlas.TxContextAPI
+	var batchTokenAPI blockatlas.TokenTxContextAPI
+	if pagedTxAPI, ok := blockatlas.AsPagedTxContextAPI(p); ok {
+		if c != nil {
+			pagedTxAPI = &cache.PagedTxAPI{PagedTxContextAPI: pagedTxAPI, Cache: c}
+		}
+		makePagedTxRoute(router(capPagedTx), p, pagedTxAPI)
+		batchAPI = pagedTxAPI
+	} else if txAPI, ok := blockatlas.AsTxContextAPI(p); ok {
+		if c != nil {
+			txAPI = &cache.TxAPI{TxContextAPI: txAPI, Cache: c}
+		}
+		makeTxRoute(router(capTx), p, txAPI)
+		batchAPI = txAPI
+	}
+	if tokenTxAPI, ok := blockatlas.AsTokenTxContextAPI(p); ok {
+		if c != nil {
+			tokenTxAPI = &cache.TokenTxAPI{TokenTxContextAPI: tokenTxAPI, Cache: c}
+		}
+		makeTokenTxRoute(router(capTokenTx), p, tokenTxAPI)
+		batchTokenAPI = tokenTxAPI
+	}
+	if batchAPI != nil {
+		makeBatchTxRoute(router(capBatchTx), p, batchAPI, batchTokenAPI)
+	}
+	if txByIDAPI, ok := blockatlas.AsTxByIDContextAPI(p); ok {
+		makeTxByIDRoute(router(capTxByID), txByIDAPI)
+	}
+	balanceAPI, hasBalance := blockatlas.AsBalanceContextAPI(p)
+	if hasBalance {
+		makeBalanceRoute(router(capBalance), balanceAPI)
+	}
+	if hasBalance || batchAPI != nil {
+		portfolioSources[p.Coin().ID] = &portfolioSource{
+			platform:   p,
+			txAPI:      batchAPI,
+			balanceAPI: balanceAPI,
+		}
+	}
+	if tokenListAPI, ok := blockatlas.AsTokenListContextAPI(p); ok {
+		makeTokenListRoute(router(capTokenList), tokenListAPI)
 	}
-	if txAPI, ok := p.(blockatlas.TxAPI); ok {
-		makeTxRoute(getRouter(g, handle), txAPI)
+
+	// Capabilities without routes of their own
+	if _, ok := blockatlas.AsPendingTxContextAPI(p); ok {
+		info.add(capPendingTx)
+	}
+	if _, ok := blockatlas.AsBlockContextAPI(p); ok {
+		info.add(capBlock)
 	}
-	if tokenTxAPI, ok := p.(blockatlas.TokenTxAPI); ok {
-		makeTokenTxRoute(getRouter(g, handle), tokenTxAPI)
+	if _, ok := p.(blockatlas.AddressValidator); ok {
+		info.add(capAddressValidation)
 	}
 }
 
@@ -42,7 +115,48 @@ func getRouter(router gin.IRouter, handle string) gin.IRouter {
 		path := fmt.Sprintf("/%s", handle)
 		logrus.Debugf("Registering %s", path)
 		group := router.Group(path)
+		group.Use(withDeadline(requestTimeout(handle)))
 		routers[handle] = group
 		return group
 	}
 }
+
+// loadCacheStore returns the store of cached responses
+// selected by cache.backend, nil if caching is disabled
+func loadCacheStore() cache.Store {
+	switch backend := viper.GetString("cache.backend"); backend {
+	case "memory":
+		return cache.NewLRU(viper.GetInt("cache.size"))
+	case "redis":
+		return cache.NewRedis(observerStorage.Redis())
+	case "", "none":
+		return nil
+	default:
+		logrus.WithField("backend", backend).Fatal("Unknown cache backend")
+		return nil
+	}
+}
+
+// requestTimeout returns the deadline of requests to the routes of a platform
+// (<handle>.request_timeout, falls back to api.request_timeout)
+func requestTimeout(handle string) time.Duration {
+	key := fmt.Sprintf("%s.request_timeout", handle)
+	if viper.IsSet(key) {
+		return viper.GetDuration(key)
+	}
+	return viper.GetDuration("api.request_timeout")
+}
+
+// withDeadline cancels the context of requests after the timeout,
+// stopping all upstream calls made on their behalf
+func withDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
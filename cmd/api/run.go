@@ -1,12 +1,21 @@
 package api
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/health"
+	"github.com/trustwallet/blockatlas/metrics"
 	observerStorage "github.com/trustwallet/blockatlas/observer/storage"
+	"github.com/trustwallet/blockatlas/platform"
 	"github.com/trustwallet/blockatlas/util"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 var Cmd = cobra.Command{
@@ -29,16 +38,46 @@ func run(_ *cobra.Command, args []string) {
 	gin.SetMode(viper.GetString("gin.mode"))
 	engine = gin.Default()
 	engine.Use(util.CheckReverseProxy)
+	engine.Use(metrics.Middleware)
 	engine.GET("/", getRoot)
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
+	engine.NoRoute(func(c *gin.Context) {
+		blockatlas.RenderError(c, "", blockatlas.ErrNotFound)
+	})
 
 	loadPlatforms(engine)
+	setupHealthAPI(engine.Group("/health"))
+	prober = &health.Prober{
+		Platforms: platform.Platforms,
+		Interval:  viper.GetDuration("health.probe_interval"),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go prober.Run(ctx)
 	if observerStorage.App != nil {
 		observerAPI := engine.Group("/observer/v1")
 		setupObserverAPI(observerAPI)
 	}
 
-	logrus.WithField("bind", bind).Info("Running application")
-	if err := engine.Run(bind); err != nil {
-		logrus.WithError(err).Fatal("Application failed")
+	server := &http.Server{Addr: bind, Handler: engine}
+	go func() {
+		logrus.WithField("bind", bind).Info("Running application")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.WithError(err).Fatal("Application failed")
+		}
+	}()
+
+	<-ctx.Done()
+	// A second signal kills the process
+	stop()
+	logrus.Info("Shutting down, draining requests")
+
+	timeout := viper.GetDuration("api.shutdown_timeout")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logrus.WithError(err).Error("Failed to drain requests")
+		return
 	}
+	logrus.Info("Exiting cleanly")
 }
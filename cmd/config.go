@@ -50,6 +50,15 @@ func loadDefaults() {
 	viper.SetDefault("observer.backlog", 3 * time.Hour)
 	viper.SetDefault("observer.backlog_max_blocks", 200)
 	viper.SetDefault("observer.stream_conns", 16)
+	viper.SetDefault("observer.metrics_bind", ":8421")
+	viper.SetDefault("observer.shutdown_timeout", 30 * time.Second)
+	viper.SetDefault("health.probe_interval", time.Minute)
+	viper.SetDefault("api.request_timeout", 30 * time.Second)
+	viper.SetDefault("api.shutdown_timeout", 15 * time.Second)
+	viper.SetDefault("api.batch_max_addresses", 100)
+	viper.SetDefault("api.batch_concurrency", 8)
+	viper.SetDefault("cache.backend", "memory")
+	viper.SetDefault("cache.size", 10000)
 
 	// All platforms with public RPC endpoints
 	viper.SetDefault("binance.api", "https://explorer.binance.org/api/v1")
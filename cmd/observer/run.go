@@ -6,10 +6,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/metrics"
 	"github.com/trustwallet/blockatlas/observer"
 	observerStorage "github.com/trustwallet/blockatlas/observer/storage"
 	"github.com/trustwallet/blockatlas/platform"
+	"net/http"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -25,9 +30,9 @@ func run(_ *cobra.Command, _ []string) {
 		logrus.Fatal("Observer is not enabled")
 	}
 
-	var blockAPIs []blockatlas.BlockAPI
+	var blockAPIs []blockatlas.BlockContextAPI
 	for _, api := range platform.Platforms {
-		if blockAPI, ok := api.(blockatlas.BlockAPI); ok {
+		if blockAPI, ok := blockatlas.AsBlockContextAPI(api); ok {
 			blockAPIs = append(blockAPIs, blockAPI)
 		}
 	}
@@ -37,6 +42,13 @@ func run(_ *cobra.Command, _ []string) {
 		logrus.Fatal("No APIs to observe")
 	}
 
+	metricsServer := serveMetrics(viper.GetString("observer.metrics_bind"))
+	defer metricsServer.Close()
+
+	// Streams stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	minInterval := viper.GetDuration("observer.min_poll")
 	backlogTime := viper.GetDuration("observer.backlog")
 
@@ -65,13 +77,14 @@ func run(_ *cobra.Command, _ []string) {
 			PollInterval: pollInterval,
 			BacklogCount: backlogCount,
 		}
-		blocks := stream.Execute(context.Background())
+		blocks := stream.Execute(ctx)
 
 		// Check for transaction events
 		obs := observer.Observer{
 			Storage: observerStorage.App,
 			Coin:    coin.ID,
 		}
+		obs.Validator, _ = api.(blockatlas.AddressValidator)
 		events := obs.Execute(blocks)
 
 		// Dispatch events
@@ -88,7 +101,36 @@ func run(_ *cobra.Command, _ []string) {
 		}).Info("Observing")
 	}
 
-	wg.Wait()
+	<-ctx.Done()
+	// A second signal kills the process
+	stop()
+	logrus.Info("Shutting down, finishing queued events")
+
+	// Streams close their channels once stopped, the observers
+	// and dispatchers return after handling the remaining blocks
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logrus.Info("Exiting cleanly")
+	case <-time.After(viper.GetDuration("observer.shutdown_timeout")):
+		logrus.Error("Timed out finishing queued events")
+	}
+}
 
-	logrus.Info("Exiting cleanly")
+// serveMetrics exposes the metrics of the worker at /metrics
+func serveMetrics(bind string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	server := &http.Server{Addr: bind, Handler: mux}
+	go func() {
+		logrus.WithField("bind", bind).Info("Serving metrics")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.WithError(err).Fatal("Metrics server failed")
+		}
+	}()
+	return server
 }
@@ -13,6 +13,7 @@ import (
 type Coin struct {
 	ID          uint   `yaml:"id"`            // SLIP-44 ID (e.g. 242)
 	Handle      string `yaml:"handle"`        // Trust Wallet handle (e.g. nimiq)
+	Platform    string `yaml:"platform"`      // Platform implementing the coin if not the handle (e.g. ethereum)
 	Symbol      string `yaml:"symbol"`        // Symbol of native currency
 	Title       string `yaml:"name"`          // Full name of native currency
 	Decimals    uint   `yaml:"decimals"`      // Number of decimals
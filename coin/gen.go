@@ -41,15 +41,18 @@ package coin
 
 const (
 {{- range .Coins }}
-	{{ .Symbol }} = "{{ .Handle }}"
+	{{ .Symbol }} = {{ .ID }}
 {{- end }}
 )
 
-var Coins = map[string]Coin{
+var Coins = map[uint]Coin{
 {{- range .Coins }}
-	"{{ .Handle }}": {
+	{{ .ID }}: {
 		ID: {{ .ID }},
 		Handle: "{{ .Handle }}",
+		{{- if .Platform }}
+		Platform: "{{ .Platform }}",
+		{{- end }}
 		Symbol: "{{ .Symbol }}",
 		Title: "{{ .Title }}",
 		Decimals: {{ .Decimals }},
@@ -1,39 +1,39 @@
 // Code generated by go generate; DO NOT EDIT.
 // This file was generated by robots at
-// 2019-07-07 13:44:51.351206 +0200 CEST m=+0.002852760
+// 2026-10-17 06:15:25.532643329 +0000 UTC m=+0.001460231
 // using data from coins.yml
 package coin
 
 const (
-	ETH = "ethereum"
-	ETC = "classic"
-	ICX = "icon"
-	ATOM = "cosmos"
-	XRP = "ripple"
-	XLM = "stellar"
-	POA = "poa"
-	TRX = "tron"
-	NIM = "nimiq"
-	IOTX = "iotex"
-	ZIL = "zilliqa"
-	AION = "aion"
-	THETA = "theta"
-	BNB = "binance"
-	VET = "vechain"
-	CLO = "callisto"
-	TOMO = "tomochain"
-	TT = "thundertoken"
-	ONT = "ontology"
-	XTZ = "tezos"
-	KIN = "kin"
-	GO = "gochain"
-	WAN = "wanchain"
-	WAVES = "waves"
-	SEM = "semux"
+	ETH = 60
+	ETC = 61
+	ICX = 74
+	ATOM = 118
+	XRP = 144
+	XLM = 148
+	POA = 178
+	TRX = 195
+	NIM = 242
+	IOTX = 304
+	ZIL = 313
+	AION = 425
+	THETA = 500
+	BNB = 714
+	VET = 818
+	CLO = 820
+	TOMO = 889
+	TT = 1001
+	ONT = 1024
+	XTZ = 1729
+	KIN = 2017
+	GO = 6060
+	WAN = 5718350
+	WAVES = 5741564
+	SEM = 7562605
 )
 
-var Coins = map[string]Coin{
-	"ethereum": {
+var Coins = map[uint]Coin{
+	60: {
 		ID: 60,
 		Handle: "ethereum",
 		Symbol: "ETH",
@@ -41,15 +41,16 @@ var Coins = map[string]Coin{
 		Decimals: 18,
 		SampleAddr: "0xfc10cab6a50a1ab10c56983c80cc82afc6559cf1",
 	},
-	"classic": {
+	61: {
 		ID: 61,
 		Handle: "classic",
+		Platform: "ethereum",
 		Symbol: "ETC",
 		Title: "Ether Classic",
 		Decimals: 18,
 		SampleAddr: "0xf3524415b6D873205B4c3Cda783527b2aC4daAA9",
 	},
-	"icon": {
+	74: {
 		ID: 74,
 		Handle: "icon",
 		Symbol: "ICX",
@@ -57,7 +58,7 @@ var Coins = map[string]Coin{
 		Decimals: 18,
 		SampleAddr: "hxee691e7bccc4eb11fee922896e9f51490e62b12e",
 	},
-	"cosmos": {
+	118: {
 		ID: 118,
 		Handle: "cosmos",
 		Symbol: "ATOM",
@@ -65,7 +66,7 @@ var Coins = map[string]Coin{
 		Decimals: 6,
 		SampleAddr: "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
 	},
-	"ripple": {
+	144: {
 		ID: 144,
 		Handle: "ripple",
 		Symbol: "XRP",
@@ -73,7 +74,7 @@ var Coins = map[string]Coin{
 		Decimals: 6,
 		SampleAddr: "rMQ98K56yXJbDGv49ZSmW51sLn94Xe1mu1",
 	},
-	"stellar": {
+	148: {
 		ID: 148,
 		Handle: "stellar",
 		Symbol: "XLM",
@@ -81,15 +82,16 @@ var Coins = map[string]Coin{
 		Decimals: 7,
 		SampleAddr: "GDKIJJIKXLOM2NRMPNQZUUYK24ZPVFC6426GZAEP3KUK6KEJLACCWNMX",
 	},
-	"poa": {
+	178: {
 		ID: 178,
 		Handle: "poa",
+		Platform: "ethereum",
 		Symbol: "POA",
 		Title: "Poa",
 		Decimals: 18,
 		SampleAddr: "0x1fddEc96688e0538A316C64dcFd211c491ECf0d8",
 	},
-	"tron": {
+	195: {
 		ID: 195,
 		Handle: "tron",
 		Symbol: "TRX",
@@ -97,7 +99,7 @@ var Coins = map[string]Coin{
 		Decimals: 6,
 		SampleAddr: "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
 	},
-	"nimiq": {
+	242: {
 		ID: 242,
 		Handle: "nimiq",
 		Symbol: "NIM",
@@ -106,23 +108,23 @@ var Coins = map[string]Coin{
 		BlockTime: 60000,
 		SampleAddr: "NQ86 2H8F YGU5 RM77 QSN9 LYLH C56A CYYR 0MLA",
 	},
-	"iotex": {
+	304: {
 		ID: 304,
 		Handle: "iotex",
 		Symbol: "IOTX",
 		Title: "IoTeX",
-		Decimals: 0,
+		Decimals: 18,
 		SampleAddr: "io1mwekae7qqwlr23220k5n9z3fmjxz72tuchra3m",
 	},
-	"zilliqa": {
+	313: {
 		ID: 313,
 		Handle: "zilliqa",
 		Symbol: "ZIL",
 		Title: "Zilliqa",
-		Decimals: 0,
+		Decimals: 12,
 		SampleAddr: "0x88aF5BA10796D9091D6893eED4db23ef0bbbCa37",
 	},
-	"aion": {
+	425: {
 		ID: 425,
 		Handle: "aion",
 		Symbol: "AION",
@@ -130,56 +132,59 @@ var Coins = map[string]Coin{
 		Decimals: 18,
 		SampleAddr: "0xa07981da70ce919e1db5f051c3c386eb526e6ce8b9e2bfd56e3f3d754b0a17f3",
 	},
-	"theta": {
+	500: {
 		ID: 500,
 		Handle: "theta",
 		Symbol: "THETA",
 		Title: "Theta",
-		Decimals: 0,
+		Decimals: 18,
 		SampleAddr: "0xac0eeb6ee3e32e2c74e14ac74155063e4f4f981f",
 	},
-	"binance": {
+	714: {
 		ID: 714,
 		Handle: "binance",
 		Symbol: "BNB",
 		Title: "Binance",
-		Decimals: 18,
+		Decimals: 8,
 		BlockTime: 1000,
 		SampleAddr: "tbnb1fhr04azuhcj0dulm7ka40y0cqjlafwae9k9gk2",
 	},
-	"vechain": {
+	818: {
 		ID: 818,
 		Handle: "vechain",
 		Symbol: "VET",
 		Title: "VeChain Token",
-		Decimals: 0,
+		Decimals: 18,
 		SampleAddr: "0xB5e883349e68aB59307d1604555AC890fAC47128",
 	},
-	"callisto": {
+	820: {
 		ID: 820,
 		Handle: "callisto",
+		Platform: "ethereum",
 		Symbol: "CLO",
 		Title: "Callisto",
 		Decimals: 18,
 		SampleAddr: "0x39ec1c88a7a7c1a575e8c8f42eff7630d9278179",
 	},
-	"tomochain": {
+	889: {
 		ID: 889,
 		Handle: "tomochain",
+		Platform: "ethereum",
 		Symbol: "TOMO",
 		Title: "TOMO",
-		Decimals: 0,
+		Decimals: 18,
 		SampleAddr: "0x7daa83030e3086477b79b6e757ca8608899fe783",
 	},
-	"thundertoken": {
+	1001: {
 		ID: 1001,
 		Handle: "thundertoken",
+		Platform: "ethereum",
 		Symbol: "TT",
 		Title: "ThunderCore",
 		Decimals: 18,
 		SampleAddr: "0x0ad80a408eac4f17ba0a9de8a12d8736f60700c3",
 	},
-	"ontology": {
+	1024: {
 		ID: 1024,
 		Handle: "ontology",
 		Symbol: "ONT",
@@ -187,7 +192,7 @@ var Coins = map[string]Coin{
 		Decimals: 0,
 		SampleAddr: "AUyL4TZ1zFEcSKDJrjFnD7vsq5iFZMZqT7",
 	},
-	"tezos": {
+	1729: {
 		ID: 1729,
 		Handle: "tezos",
 		Symbol: "XTZ",
@@ -195,31 +200,34 @@ var Coins = map[string]Coin{
 		Decimals: 6,
 		SampleAddr: "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
 	},
-	"kin": {
+	2017: {
 		ID: 2017,
 		Handle: "kin",
+		Platform: "stellar",
 		Symbol: "KIN",
 		Title: "Kin",
 		Decimals: 5,
 		SampleAddr: "GBHKUZ7C2SZ5N3X2S7O6TT6LNUWSEA2BXMSR5GTTSR6VZARSVAXIQNGH",
 	},
-	"gochain": {
+	6060: {
 		ID: 6060,
 		Handle: "gochain",
+		Platform: "ethereum",
 		Symbol: "GO",
 		Title: "GoChain GO",
 		Decimals: 18,
 		SampleAddr: "0x76c2F81716A8D198a00502Ae9a59126418899FDe",
 	},
-	"wanchain": {
+	5718350: {
 		ID: 5718350,
 		Handle: "wanchain",
+		Platform: "ethereum",
 		Symbol: "WAN",
 		Title: "Wanchain",
 		Decimals: 18,
 		SampleAddr: "0x36cEdc3A9d969306AF4F7CA2b83ABBf74095914d",
 	},
-	"waves": {
+	5741564: {
 		ID: 5741564,
 		Handle: "waves",
 		Symbol: "WAVES",
@@ -227,7 +235,7 @@ var Coins = map[string]Coin{
 		Decimals: 8,
 		SampleAddr: "3P7wz6TXienpw3BHe8eHUEuZWb6WE58kgnQ",
 	},
-	"semux": {
+	7562605: {
 		ID: 7562605,
 		Handle: "semux",
 		Symbol: "SEM",
@@ -1,12 +1,219 @@
 package blockatlas
 
-import "errors"
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+)
+
+// ErrorKind describes why a request failed
+type ErrorKind string
+
+// Kinds of errors
+const (
+	KindInvalidRequest ErrorKind = "invalid_request"
+	KindInvalidAddr    ErrorKind = "invalid_address"
+	KindInvalidCursor  ErrorKind = "invalid_cursor"
+	KindUnauthorized   ErrorKind = "unauthorized"
+	KindNotFound       ErrorKind = "not_found"
+	KindUnsupported    ErrorKind = "unsupported"
+	KindUnavailable    ErrorKind = "upstream_unavailable"
+	KindRateLimited    ErrorKind = "rate_limited"
+	KindDecode         ErrorKind = "decode_failure"
+	KindInternal       ErrorKind = "internal"
+)
+
+// Error is an error of a request to Block Atlas
+// or to the upstream API of a platform
+type Error struct {
+	// Kind of error
+	Kind ErrorKind
+	// Handle of the platform the error occurred on (optional)
+	Platform string
+	// HTTP status returned by the upstream API (optional)
+	Status int
+	// Short description safe to show to clients
+	Message string
+	// Underlying error (optional)
+	Err error
+}
+
+// ErrInvalidRequest signals that the request is malformed
+var ErrInvalidRequest = &Error{Kind: KindInvalidRequest, Message: "invalid request"}
 
 // ErrSourceConn signals that the connection to the source API failed
-var ErrSourceConn = errors.New("connection to servers failed")
+var ErrSourceConn = &Error{Kind: KindUnavailable, Message: "connection to servers failed"}
 
 // ErrInvalidAddr signals that the requested address is invalid
-var ErrInvalidAddr = errors.New("invalid address")
+var ErrInvalidAddr = &Error{Kind: KindInvalidAddr, Message: "invalid address"}
 
 // ErrNotFound signals that the resource has not been found
-var ErrNotFound = errors.New("not found")
+var ErrNotFound = &Error{Kind: KindNotFound, Message: "not found"}
+
+// ErrUnsupported signals that the platform does not support the request
+var ErrUnsupported = &Error{Kind: KindUnsupported, Message: "not supported by platform"}
+
+// ErrInvalidCursor signals that the requested page cursor is invalid
+var ErrInvalidCursor = &Error{Kind: KindInvalidCursor, Message: "invalid cursor"}
+
+// ErrRateLimited signals that the source API rejected requests
+// because too many have been sent
+var ErrRateLimited = &Error{Kind: KindRateLimited, Message: "rate limited by servers"}
+
+// ErrStatus returns the error of an unexpected
+// HTTP status returned by a source API
+func ErrStatus(status int) *Error {
+	e := &Error{
+		Status:  status,
+		Message: fmt.Sprintf("servers returned http %d", status),
+	}
+	switch {
+	case status == http.StatusNotFound:
+		e.Kind = KindNotFound
+	case status == http.StatusTooManyRequests:
+		e.Kind = KindRateLimited
+	default:
+		e.Kind = KindUnavailable
+	}
+	return e
+}
+
+// ErrDecode signals that the response of a source API could not be read
+func ErrDecode(err error) *Error {
+	return &Error{Kind: KindDecode, Message: "failed to decode response", Err: err}
+}
+
+// ToError returns err as *Error.
+// Errors of other types are internal errors.
+func ToError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	// The request was canceled or exceeded its deadline
+	switch err {
+	case context.DeadlineExceeded:
+		return &Error{Kind: KindUnavailable, Message: "request timed out", Err: err}
+	case context.Canceled:
+		return &Error{Kind: KindUnavailable, Message: "request canceled", Err: err}
+	}
+	// Failed request to a source API
+	if ue, ok := err.(*url.Error); ok {
+		if e, ok := ue.Err.(*Error); ok {
+			return e
+		}
+		msg := ErrSourceConn.Message
+		if ue.Timeout() {
+			msg = "request to servers timed out"
+		}
+		return &Error{Kind: KindUnavailable, Message: msg, Err: err}
+	}
+	return &Error{Kind: KindInternal, Message: "internal error", Err: err}
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if e.Platform != "" {
+		msg = fmt.Sprintf("%s: %s", e.Platform, msg)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error of the same kind
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind
+}
+
+// Retryable says whether the same request might succeed later
+func (e *Error) Retryable() bool {
+	switch e.Kind {
+	case KindUnavailable, KindRateLimited:
+		return true
+	case KindDecode, KindInternal:
+		return e.Status >= 500
+	default:
+		return false
+	}
+}
+
+// WithPlatform returns a copy of the error that
+// occurred on the platform with the given handle
+func (e *Error) WithPlatform(handle string) *Error {
+	c := *e
+	c.Platform = handle
+	return &c
+}
+
+// HTTPStatus returns the HTTP status of the error
+// as returned by the Block Atlas API
+func (e *Error) HTTPStatus() int {
+	switch e.Kind {
+	case KindInvalidRequest, KindInvalidAddr, KindInvalidCursor:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindUnsupported:
+		return http.StatusNotImplemented
+	case KindUnavailable, KindRateLimited:
+		return http.StatusServiceUnavailable
+	case KindDecode:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorJSON is the body of error responses
+type errorJSON struct {
+	Error errorDetailJSON `json:"error"`
+}
+
+// errorDetailJSON describes an error in JSON
+type errorDetailJSON struct {
+	Kind           ErrorKind `json:"kind"`
+	Message        string    `json:"message"`
+	Platform       string    `json:"platform,omitempty"`
+	UpstreamStatus int       `json:"upstream_status,omitempty"`
+	Retryable      bool      `json:"retryable"`
+}
+
+func newErrorDetailJSON(e *Error) errorDetailJSON {
+	return errorDetailJSON{
+		Kind:           e.Kind,
+		Message:        e.Message,
+		Platform:       e.Platform,
+		UpstreamStatus: e.Status,
+		Retryable:      e.Retryable(),
+	}
+}
+
+// RenderError aborts a request with the JSON representation of err.
+// The platform handle is added if the error does not carry one yet.
+func RenderError(c *gin.Context, handle string, err error) {
+	e := ToError(err)
+	if e.Platform == "" && handle != "" {
+		e = e.WithPlatform(handle)
+	}
+
+	status := e.HTTPStatus()
+	if status >= http.StatusInternalServerError {
+		logrus.WithError(e).WithField("path", c.Request.URL.Path).
+			Error("Request failed")
+	}
+
+	body := errorJSON{Error: newErrorDetailJSON(e)}
+	c.AbortWithStatusJSON(status, &body)
+}
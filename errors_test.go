@@ -0,0 +1,58 @@
+package blockatlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestErrStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		kind      ErrorKind
+		retryable bool
+	}{
+		{http.StatusNotFound, KindNotFound, false},
+		{http.StatusTooManyRequests, KindRateLimited, true},
+		{http.StatusBadGateway, KindUnavailable, true},
+	}
+
+	for _, test := range tests {
+		err := ErrStatus(test.status)
+		if err.Kind != test.kind {
+			t.Errorf("%d: expected kind %s, got %s", test.status, test.kind, err.Kind)
+		}
+		if err.Retryable() != test.retryable {
+			t.Errorf("%d: expected retryable %t", test.status, test.retryable)
+		}
+		if err.Status != test.status {
+			t.Errorf("%d: upstream status not kept", test.status)
+		}
+	}
+}
+
+func TestToError(t *testing.T) {
+	if err := ToError(ErrNotFound); err != ErrNotFound {
+		t.Error("typed error was not kept")
+	}
+	err := ToError(fmt.Errorf("boom"))
+	if err.Kind != KindInternal || err.HTTPStatus() != http.StatusInternalServerError {
+		t.Error("untyped error not internal")
+	}
+	err = ToError(&url.Error{Op: "Get", URL: "http://localhost", Err: fmt.Errorf("connection refused")})
+	if err.Kind != KindUnavailable || !err.Retryable() {
+		t.Error("failed request not unavailable")
+	}
+	err = ToError(context.DeadlineExceeded)
+	if err.Kind != KindUnavailable {
+		t.Error("exceeded deadline not unavailable")
+	}
+	if ErrUnsupported.HTTPStatus() != http.StatusNotImplemented || ErrUnsupported.Retryable() {
+		t.Error("unsupported request not final")
+	}
+	if !ErrNotFound.WithPlatform("nimiq").Is(ErrNotFound) {
+		t.Error("error with platform does not match its kind")
+	}
+}
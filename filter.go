@@ -0,0 +1,133 @@
+package blockatlas
+
+import "github.com/trustwallet/blockatlas/util"
+
+// TxFilter selects transactions of a page.
+// Zero values match all transactions.
+type TxFilter struct {
+	// Types of metadata (e.g. TxTransfer)
+	Types []string
+	// Statuses (e.g. StatusCompleted)
+	Statuses []string
+	// Direction relative to Address
+	Direction string
+	Address   string
+	// Converts addresses of transactions into the
+	// form of Address before comparing them (optional)
+	Canonical func(address string) string
+	// Range of Tx.Date, inclusive
+	Since, Until int64
+	// Range of Tx.Block, inclusive
+	FromBlock, ToBlock uint64
+	// Minimum value of the metadata, transactions
+	// without value are dropped if set
+	MinValue Amount
+}
+
+// Filter returns the transactions matching f
+func (r TxPage) Filter(f *TxFilter) TxPage {
+	if f == nil {
+		return r
+	}
+	txs := make(TxPage, 0, len(r))
+	for i := range r {
+		if f.Match(&r[i]) {
+			txs = append(txs, r[i])
+		}
+	}
+	return txs
+}
+
+// Match returns whether a transaction matches f
+func (f *TxFilter) Match(tx *Tx) bool {
+	if len(f.Types) > 0 && !tx.hasType(f.Types) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !util.Contains(f.Statuses, tx.Status) {
+		return false
+	}
+	if f.Direction != "" && tx.DirectionOf(f.Address, f.Canonical) != f.Direction {
+		return false
+	}
+	if (f.Since != 0 && tx.Date < f.Since) || (f.Until != 0 && tx.Date > f.Until) {
+		return false
+	}
+	if (f.FromBlock != 0 && tx.Block < f.FromBlock) || (f.ToBlock != 0 && tx.Block > f.ToBlock) {
+		return false
+	}
+	if f.MinValue != "" {
+		value, ok := tx.Value()
+		if !ok {
+			return false
+		}
+		if cmp, err := value.Cmp(f.MinValue); err != nil || cmp < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hasType says whether the metadata of the transaction
+// or one of its operations is of one of the types
+func (t *Tx) hasType(types []string) bool {
+	for _, meta := range t.metas() {
+		if typ, ok := metaType(meta); ok && util.Contains(types, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the highest value of the metadata of the transaction
+// and its operations, false if none of them has a value
+func (t *Tx) Value() (max Amount, ok bool) {
+	for _, meta := range t.metas() {
+		value, hasValue := metaAmount(meta)
+		if !hasValue {
+			continue
+		}
+		if ok {
+			if cmp, err := value.Cmp(max); err != nil || cmp <= 0 {
+				continue
+			}
+		}
+		max, ok = value, true
+	}
+	return max, ok
+}
+
+// metas returns the metadata of the operations, or that
+// of the transaction if it has a single operation
+func (t *Tx) metas() []interface{} {
+	if len(t.Ops) == 0 {
+		return []interface{}{t.Meta}
+	}
+	metas := make([]interface{}, len(t.Ops))
+	for i := range t.Ops {
+		metas[i] = t.Ops[i].Meta
+	}
+	return metas
+}
+
+// metaAmount returns the value of a metadata object,
+// false if the metadata has none
+func metaAmount(meta interface{}) (Amount, bool) {
+	switch meta := meta.(type) {
+	case Transfer:
+		return meta.Value, true
+	case NativeTokenTransfer:
+		return meta.Value, true
+	case TokenTransfer:
+		return meta.Value, true
+	case Delegate:
+		return meta.Value, true
+	case Undelegate:
+		return meta.Value, true
+	case ClaimRewards:
+		return meta.Value, true
+	case Vote:
+		return meta.Value, true
+	default:
+		return "", false
+	}
+}
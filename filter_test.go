@@ -0,0 +1,74 @@
+package blockatlas
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTxFilter_Match(t *testing.T) {
+	tx := Tx{
+		ID:     "1",
+		From:   "0xabc",
+		To:     "0xdef",
+		Date:   100,
+		Block:  10,
+		Status: StatusCompleted,
+		Meta:   Transfer{Value: "500"},
+	}
+	tests := []struct {
+		name   string
+		filter TxFilter
+		want   bool
+	}{
+		{"empty", TxFilter{}, true},
+		{"type", TxFilter{Types: []string{TxTokenTransfer, TxTransfer}}, true},
+		{"other type", TxFilter{Types: []string{TxTokenTransfer}}, false},
+		{"status", TxFilter{Statuses: []string{StatusCompleted}}, true},
+		{"other status", TxFilter{Statuses: []string{StatusPending}}, false},
+		{"outgoing", TxFilter{Direction: DirectionOutgoing, Address: "0xabc"}, true},
+		{"incoming", TxFilter{Direction: DirectionIncoming, Address: "0xabc"}, false},
+		{"canonical", TxFilter{Direction: DirectionIncoming, Address: "0XDEF", Canonical: strings.ToUpper}, true},
+		{"date range", TxFilter{Since: 100, Until: 100}, true},
+		{"before since", TxFilter{Since: 101}, false},
+		{"after until", TxFilter{Until: 99}, false},
+		{"block range", TxFilter{FromBlock: 5, ToBlock: 10}, true},
+		{"after to block", TxFilter{ToBlock: 9}, false},
+		{"min value", TxFilter{MinValue: "500"}, true},
+		{"below min value", TxFilter{MinValue: "501"}, false},
+	}
+	for _, test := range tests {
+		if got := test.filter.Match(&tx); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+
+	noValue := Tx{Meta: CollectibleTransfer{}}
+	if (&TxFilter{MinValue: "0"}).Match(&noValue) {
+		t.Error("expected transactions without value to be dropped by min value")
+	}
+}
+
+func TestTxFilter_Match_Ops(t *testing.T) {
+	// Claim rewards and delegate them again
+	tx := Tx{ID: "1", Meta: ClaimRewards{Value: "10"}}
+	tx.SetOps([]Op{
+		{Meta: ClaimRewards{Validator: "validator", Value: "10"}},
+		{Meta: Delegate{Validator: "validator", Value: "200"}},
+	})
+	tests := []struct {
+		name   string
+		filter TxFilter
+		want   bool
+	}{
+		{"type of first op", TxFilter{Types: []string{TxClaimRewards}}, true},
+		{"type of other op", TxFilter{Types: []string{TxDelegate}}, true},
+		{"other type", TxFilter{Types: []string{TxTransfer}}, false},
+		{"min value of other op", TxFilter{MinValue: "200"}, true},
+		{"above values of ops", TxFilter{MinValue: "201"}, false},
+	}
+	for _, test := range tests {
+		if got := test.filter.Match(&tx); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
@@ -1,17 +1,24 @@
 module github.com/trustwallet/blockatlas
 
-go 1.12
+go 1.16
 
 require (
-	github.com/gin-gonic/gin v1.4.0
+	github.com/davecgh/go-spew v1.1.1
+	github.com/gin-gonic/gin v1.7.7
 	github.com/go-redis/redis v6.15.2+incompatible
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.1 // indirect
 	github.com/mr-tron/base58 v1.1.2
+	github.com/prometheus/client_golang v1.2.1
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/cast v1.3.0
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/viper v1.4.0
-	github.com/stretchr/testify v1.3.0
+	github.com/stretchr/testify v1.4.0
 	github.com/valyala/fastjson v1.4.1
 	github.com/ybbus/jsonrpc v2.1.2+incompatible
-	gopkg.in/yaml.v2 v2.2.2
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
+	gopkg.in/go-playground/validator.v8 v8.18.2 // indirect
+	gopkg.in/yaml.v2 v2.2.8
 )
@@ -0,0 +1,168 @@
+// Package health probes the source APIs of platforms.
+package health
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Checks done by probes
+const (
+	CheckBlock    = "block"
+	CheckTxs      = "txs"
+	CheckBalance  = "balance"
+	CheckTokens   = "tokens"
+	CheckTokenTxs = "token_txs"
+)
+
+// DefaultInterval is the time between probes if no interval is set
+const DefaultInterval = time.Minute
+
+// Result is the outcome of the last probe of a platform
+type Result struct {
+	Handle string `json:"handle"`
+	// Lookup done by the probe, empty if the platform can't be probed
+	Check     string `json:"check,omitempty"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+	CheckedAt int64  `json:"checked_at"`
+}
+
+// Prober probes platforms on a schedule and caches the results
+type Prober struct {
+	Platforms map[string]blockatlas.Platform
+	Interval  time.Duration
+
+	mu      sync.RWMutex
+	results map[string]Result
+}
+
+// Run probes all platforms immediately and then
+// every interval until the context is done
+func (p *Prober) Run(ctx context.Context) {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.ProbeAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProbeAll probes all platforms concurrently
+func (p *Prober) ProbeAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, platform := range p.Platforms {
+		wg.Add(1)
+		go func(platform blockatlas.Platform) {
+			defer wg.Done()
+			p.store(Probe(ctx, platform))
+		}(platform)
+	}
+	wg.Wait()
+}
+
+// Results returns the last result of every probed platform ordered by handle
+func (p *Prober) Results() []Result {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	results := make([]Result, 0, len(p.results))
+	for _, r := range p.results {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Handle < results[j].Handle
+	})
+	return results
+}
+
+// Result returns the last result of a platform
+func (p *Prober) Result(handle string) (Result, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	r, ok := p.results[handle]
+	return r, ok
+}
+
+func (p *Prober) store(r Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.results == nil {
+		p.results = make(map[string]Result)
+	}
+	p.results[r.Handle] = r
+	if !r.Healthy && r.Check != "" {
+		logrus.WithField("platform", r.Handle).WithField("check", r.Check).
+			Warn("Platform probe failed: ", r.Error)
+	}
+}
+
+// Probe does the cheapest lookup a platform supports:
+// The current block number, or lookups of the sample address
+// and token of the coin.
+// The probe is canceled when the context is done.
+func Probe(ctx context.Context, platform blockatlas.Platform) Result {
+	c := platform.Coin()
+	result := Result{Handle: c.Handle, Healthy: true}
+
+	var check func() error
+	if api, ok := blockatlas.AsBlockContextAPI(platform); ok {
+		result.Check = CheckBlock
+		check = func() error {
+			_, err := api.CurrentBlockNumberContext(ctx)
+			return err
+		}
+	}
+	if check == nil && c.SampleAddr != "" {
+		if api, ok := blockatlas.AsTxContextAPI(platform); ok {
+			result.Check = CheckTxs
+			check = func() error {
+				_, err := api.GetTxsByAddressContext(ctx, c.SampleAddr)
+				return err
+			}
+		} else if api, ok := blockatlas.AsBalanceContextAPI(platform); ok {
+			result.Check = CheckBalance
+			check = func() error {
+				_, err := api.GetBalanceContext(ctx, c.SampleAddr)
+				return err
+			}
+		} else if api, ok := blockatlas.AsTokenListContextAPI(platform); ok {
+			result.Check = CheckTokens
+			check = func() error {
+				_, err := api.GetTokenListByAddressContext(ctx, c.SampleAddr)
+				return err
+			}
+		}
+	}
+	if api, ok := blockatlas.AsTokenTxContextAPI(platform); ok && check == nil &&
+		c.SampleAddr != "" && c.SampleToken != "" {
+		result.Check = CheckTokenTxs
+		check = func() error {
+			_, err := api.GetTokenTxsByAddressContext(ctx, c.SampleAddr, c.SampleToken)
+			return err
+		}
+	}
+
+	start := time.Now()
+	if check != nil {
+		if err := check(); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+		}
+	}
+	result.LatencyMs = int64(time.Since(start) / time.Millisecond)
+	result.CheckedAt = start.Unix()
+	return result
+}
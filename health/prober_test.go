@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"testing"
+)
+
+type blockPlatform struct{ err error }
+
+func (p *blockPlatform) Init() error     { return nil }
+func (p *blockPlatform) Coin() coin.Coin { return coin.Coins[coin.NIM] }
+func (p *blockPlatform) CurrentBlockNumberContext(ctx context.Context) (int64, error) {
+	return 1, p.err
+}
+func (p *blockPlatform) GetBlockByNumberContext(ctx context.Context, num int64) (*blockatlas.Block, error) {
+	return nil, p.err
+}
+
+type balancePlatform struct{ address string }
+
+func (p *balancePlatform) Init() error     { return nil }
+func (p *balancePlatform) Coin() coin.Coin { return coin.Coins[coin.XLM] }
+func (p *balancePlatform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	p.address = address
+	return "1", nil
+}
+
+func TestProbe(t *testing.T) {
+	r := Probe(context.Background(), &blockPlatform{err: fmt.Errorf("down")})
+	if r.Check != CheckBlock || r.Healthy || r.Error != "down" {
+		t.Errorf("expected failed block check, got %+v", r)
+	}
+
+	p := &balancePlatform{}
+	r = Probe(context.Background(), p)
+	if r.Check != CheckBalance || !r.Healthy {
+		t.Errorf("expected healthy balance check, got %+v", r)
+	}
+	if p.address != coin.Coins[coin.XLM].SampleAddr {
+		t.Errorf("expected lookup of sample address, got %s", p.address)
+	}
+}
+
+func TestProber_ProbeAll(t *testing.T) {
+	prober := Prober{Platforms: map[string]blockatlas.Platform{
+		"nimiq":   &blockPlatform{},
+		"stellar": &balancePlatform{},
+	}}
+	prober.ProbeAll(context.Background())
+
+	results := prober.Results()
+	if len(results) != 2 || results[0].Handle != "nimiq" || results[1].Handle != "stellar" {
+		t.Fatalf("expected results of nimiq and stellar, got %+v", results)
+	}
+	if r, ok := prober.Result("nimiq"); !ok || !r.Healthy {
+		t.Errorf("expected healthy nimiq, got %+v", r)
+	}
+}
@@ -4,10 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/spf13/cast"
-	"github.com/trustwallet/blockatlas/util"
+	"reflect"
 	"regexp"
 	"sort"
-	"strings"
 )
 
 var matchNumber = regexp.MustCompile(`^\d+(\.\d+)?$`)
@@ -29,25 +28,14 @@ func (t *Tx) UnmarshalJSON(data []byte) error {
 
 	*t = Tx(wrapped)
 
-	switch t.Type {
-	case TxTransfer:
-		t.Meta = new(Transfer)
-	case TxNativeTokenTransfer:
-		t.Meta = new(NativeTokenTransfer)
-	case TxTokenTransfer:
-		t.Meta = new(TokenTransfer)
-	case TxCollectibleTransfer:
-		t.Meta = new(CollectibleTransfer)
-	case TxTokenSwap:
-		t.Meta = new(TokenSwap)
-	case TxContractCall:
-		t.Meta = new(ContractCall)
-	default:
+	meta, ok := newMeta(t.Type)
+	if !ok {
 		return fmt.Errorf(`unsupported tx type "%s"`, t.Type)
 	}
-	if err := json.Unmarshal(raw, t.Meta); err != nil {
+	if err := json.Unmarshal(raw, meta); err != nil {
 		return err
 	}
+	t.Meta = metaValue(meta)
 	return nil
 }
 
@@ -55,48 +43,136 @@ func (t *Tx) UnmarshalJSON(data []byte) error {
 // Sets the Type field to the currect value based on the Meta type.
 func (t *Tx) MarshalJSON() ([]byte, error) {
 	// Set type from metadata content
-	switch t.Meta.(type) {
+	var ok bool
+	if t.Type, ok = metaType(t.Meta); !ok {
+		return nil, fmt.Errorf("unsupported tx metadata")
+	}
+
+	// Set status to completed by default
+	if t.Status == "" {
+		t.Status = StatusCompleted
+	}
+
+	// Wrap the Tx type to avoid infinite recursion
+	return json.Marshal(wrappedTx(*t))
+}
+
+// Op, but with default JSON marshalling methods
+type wrappedOp Op
+
+// UnmarshalJSON creates an operation along with metadata from a JSON object.
+// Fails if the meta object can't be read.
+func (o *Op) UnmarshalJSON(data []byte) error {
+	// Wrap the Op type to avoid infinite recursion
+	var wrapped wrappedOp
+
+	var raw json.RawMessage
+	wrapped.Meta = &raw
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+
+	*o = Op(wrapped)
+
+	meta, ok := newMeta(o.Type)
+	if !ok {
+		return fmt.Errorf(`unsupported op type "%s"`, o.Type)
+	}
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return err
+	}
+	o.Meta = metaValue(meta)
+	return nil
+}
+
+// MarshalJSON creates a JSON object from an operation.
+// Sets the Type field to the currect value based on the Meta type.
+func (o *Op) MarshalJSON() ([]byte, error) {
+	var ok bool
+	if o.Type, ok = metaType(o.Meta); !ok {
+		return nil, fmt.Errorf("unsupported op metadata")
+	}
+
+	// Wrap the Op type to avoid infinite recursion
+	return json.Marshal(wrappedOp(*o))
+}
+
+// metaType returns the type name of a metadata object
+func metaType(meta interface{}) (string, bool) {
+	switch meta.(type) {
 	case Transfer, *Transfer:
-		t.Type = TxTransfer
+		return TxTransfer, true
 	case NativeTokenTransfer, *NativeTokenTransfer:
-		t.Type = TxNativeTokenTransfer
+		return TxNativeTokenTransfer, true
 	case TokenTransfer, *TokenTransfer:
-		t.Type = TxTokenTransfer
+		return TxTokenTransfer, true
 	case CollectibleTransfer, *CollectibleTransfer:
-		t.Type = TxCollectibleTransfer
+		return TxCollectibleTransfer, true
 	case TokenSwap, *TokenSwap:
-		t.Type = TxTokenSwap
+		return TxTokenSwap, true
 	case ContractCall, *ContractCall:
-		t.Type = TxContractCall
+		return TxContractCall, true
+	case Delegate, *Delegate:
+		return TxDelegate, true
+	case Undelegate, *Undelegate:
+		return TxUndelegate, true
+	case ClaimRewards, *ClaimRewards:
+		return TxClaimRewards, true
+	case Vote, *Vote:
+		return TxVote, true
 	default:
-		return nil, fmt.Errorf("unsupported tx metadata")
+		return "", false
 	}
+}
 
-	// Set status to completed by default
-	if t.Status == "" {
-		t.Status = StatusCompleted
+// newMeta returns an empty metadata object of a type
+func newMeta(typ string) (interface{}, bool) {
+	switch typ {
+	case TxTransfer:
+		return new(Transfer), true
+	case TxNativeTokenTransfer:
+		return new(NativeTokenTransfer), true
+	case TxTokenTransfer:
+		return new(TokenTransfer), true
+	case TxCollectibleTransfer:
+		return new(CollectibleTransfer), true
+	case TxTokenSwap:
+		return new(TokenSwap), true
+	case TxContractCall:
+		return new(ContractCall), true
+	case TxDelegate:
+		return new(Delegate), true
+	case TxUndelegate:
+		return new(Undelegate), true
+	case TxClaimRewards:
+		return new(ClaimRewards), true
+	case TxVote:
+		return new(Vote), true
+	default:
+		return nil, false
 	}
+}
 
-	// Wrap the Tx type to avoid infinite recursion
-	return json.Marshal(wrappedTx(*t))
+// metaValue dereferences a metadata object read by newMeta.
+// Metadata is stored by value like the normalizers set it.
+func metaValue(meta interface{}) interface{} {
+	return reflect.ValueOf(meta).Elem().Interface()
 }
 
 // UnmarshalJSON reads an amount from a JSON string or number.
-// Comma separators get dropped with util.DecimalToSatoshis.
+// Fails on fractional digits other than zeros, use ParseAmount
+// to read amounts that are not in the smallest unit.
 func (a *Amount) UnmarshalJSON(data []byte) error {
 	var n json.Number
 	err := json.Unmarshal(data, &n)
 	if err != nil {
 		return err
 	}
-	str := string(n)
-	if !matchNumber.MatchString(str) {
-		return fmt.Errorf("not a regular decimal number: %s", str)
-	}
-	if strings.ContainsRune(str, '.') {
-		str, _ = util.DecimalToSatoshis(str)
+	amount, err := ParseAmount(string(n), 0)
+	if err != nil {
+		return err
 	}
-	*a = Amount(str)
+	*a = amount
 	return nil
 }
 
@@ -105,27 +181,106 @@ func (a *Amount) MarshalJSON() ([]byte, error) {
 	return json.Marshal(string(*a))
 }
 
-// Sort sorts the response by date, descending
+// MarshalJSON returns a wrapped list of tokens in JSON
+func (r *TokenPage) MarshalJSON() ([]byte, error) {
+	var page struct {
+		Total  int     `json:"total"`
+		Docs   []Token `json:"docs"`
+		Status string  `json:"status"`
+	}
+	page.Docs = []Token(*r)
+	if page.Docs == nil {
+		page.Docs = make([]Token, 0)
+	}
+	page.Total = len(page.Docs)
+	page.Status = "success"
+	return json.Marshal(page)
+}
+
+// Sort sorts the response by date, descending.
+// Pending transactions come first.
 func (r *TxPage) Sort() {
 	sort.Slice(*r, func(i, j int) bool {
+		pi := (*r)[i].Status == StatusPending
+		pj := (*r)[j].Status == StatusPending
+		if pi != pj {
+			return pi
+		}
 		ti := cast.ToUint64((*r)[i].Date)
 		tj := cast.ToUint64((*r)[j].Date)
 		return ti >= tj
 	})
 }
 
-// MarshalJSON returns a wrapped list of transactions in JSON
-func (r *TxPage) MarshalJSON() ([]byte, error) {
-	var page struct {
-		Total  int    `json:"total"`
-		Docs   []Tx   `json:"docs"`
-		Status string `json:"status"`
-	}
-	page.Docs = []Tx(*r)
+// pageJSON is the envelope of transaction pages in JSON
+type pageJSON struct {
+	Total  int    `json:"total"`
+	Docs   []Tx   `json:"docs"`
+	Status string `json:"status"`
+	Next   string `json:"next,omitempty"`
+}
+
+func newPageJSON(txs []Tx) (page pageJSON) {
+	page.Docs = txs
 	if page.Docs == nil {
 		page.Docs = make([]Tx, 0)
 	}
 	page.Total = len(page.Docs)
 	page.Status = "success"
+	return
+}
+
+// MarshalJSON returns a wrapped list of transactions in JSON
+func (r *TxPage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newPageJSON(*r))
+}
+
+// MarshalJSON returns a wrapped list of transactions
+// and the cursor of the next page in JSON
+func (r *TxCursorPage) MarshalJSON() ([]byte, error) {
+	page := newPageJSON(r.Txs)
+	page.Next = r.Next
+	return json.Marshal(page)
+}
+
+// MarshalJSON returns a wrapped list of transactions
+// and the errors of failed lookups by address in JSON
+func (r *TxBatchPage) MarshalJSON() ([]byte, error) {
+	var page struct {
+		pageJSON
+		Errors map[string]errorDetailJSON `json:"errors,omitempty"`
+	}
+	page.pageJSON = newPageJSON(r.Txs)
+	if len(r.Errors) > 0 {
+		page.Errors = make(map[string]errorDetailJSON, len(r.Errors))
+		for address, err := range r.Errors {
+			page.Errors[address] = newErrorDetailJSON(ToError(err))
+		}
+	}
+	return json.Marshal(page)
+}
+
+// MarshalJSON returns a wrapped list of transactions, the balances
+// and the errors of failed lookups by coin and address in JSON
+func (r *Portfolio) MarshalJSON() ([]byte, error) {
+	var page struct {
+		pageJSON
+		Balances map[uint]map[string]Balance         `json:"balances"`
+		Errors   map[uint]map[string]errorDetailJSON `json:"errors,omitempty"`
+	}
+	page.pageJSON = newPageJSON(r.Txs)
+	page.Balances = r.Balances
+	if page.Balances == nil {
+		page.Balances = make(map[uint]map[string]Balance)
+	}
+	if len(r.Errors) > 0 {
+		page.Errors = make(map[uint]map[string]errorDetailJSON, len(r.Errors))
+		for coin, perCoin := range r.Errors {
+			page.Errors[coin] = make(map[string]errorDetailJSON, len(perCoin))
+			for address, err := range perCoin {
+				page.Errors[coin][address] = newErrorDetailJSON(ToError(err))
+			}
+		}
+	}
 	return json.Marshal(page)
 }
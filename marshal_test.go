@@ -32,7 +32,7 @@ var txModel = Tx{
 	Date:   1548954343,
 	Block:  419040,
 	Status: StatusCompleted,
-	Meta:   &Transfer{
+	Meta:   Transfer{
 		Value:    "5004160",
 	},
 }
@@ -75,3 +75,164 @@ func TestTx_MarshalJSON(t *testing.T) {
 	// Compare expected and output JSON
 	bytes.Equal(got, txJSON)
 }
+
+func TestTxCursorPage_MarshalJSON(t *testing.T) {
+	page := TxCursorPage{
+		Txs:  TxPage{txModel},
+		Next: "25",
+	}
+
+	got, err := json.Marshal(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Total int    `json:"total"`
+		Docs  []Tx   `json:"docs"`
+		Next  string `json:"next"`
+	}
+	if err := json.Unmarshal(got, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Total != 1 || len(res.Docs) != 1 {
+		t.Error("wrong number of txs")
+	}
+	if res.Next != "25" {
+		t.Errorf("expected next cursor 25, got %s", res.Next)
+	}
+}
+
+func TestTx_MarshalJSON_Ops(t *testing.T) {
+	input := txModel
+	input.SetOps([]Op{
+		{
+			From: "NQ11 P00L 2HYP TUK8 VY6L 2N22 MMBU MHHR BSAA",
+			To:   "NQ86 2H8F YGU5 RM77 QSN9 LYLH C56A CYYR 0MLA",
+			Meta: Transfer{Value: "5004160"},
+		},
+		{
+			From: "NQ11 P00L 2HYP TUK8 VY6L 2N22 MMBU MHHR BSAA",
+			To:   "NQ15 MLJN 23YB 8FBM 61TN 7LYG 2212 LVBG 4V19",
+			Meta: TokenTransfer{Symbol: "TKN", Value: "1"},
+		},
+	})
+
+	data, err := json.Marshal(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Tx
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first operation stays readable as a single-meta transaction
+	if got.Type != TxTransfer || !reflect.DeepEqual(got.Meta, Transfer{Value: "5004160"}) {
+		t.Error("first operation not mirrored into metadata")
+	}
+	if len(got.Ops) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(got.Ops))
+	}
+	if got.Ops[1].Type != TxTokenTransfer {
+		t.Errorf("expected op type %s, got %s", TxTokenTransfer, got.Ops[1].Type)
+	}
+	if !reflect.DeepEqual(got.Ops[1].Meta, TokenTransfer{Symbol: "TKN", Value: "1"}) {
+		t.Error("op metadata not equal")
+	}
+}
+
+func TestTx_MarshalJSON_Staking(t *testing.T) {
+	tests := map[string]interface{}{
+		TxDelegate:     Delegate{Validator: "validator", Value: "100"},
+		TxUndelegate:   Undelegate{Validator: "validator", Value: "100"},
+		TxClaimRewards: ClaimRewards{Validator: "validator", Value: "1"},
+		TxVote:         Vote{Candidate: "candidate", Value: "10"},
+	}
+
+	for typ, meta := range tests {
+		input := txModel
+		input.Meta = meta
+
+		data, err := json.Marshal(&input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Tx
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Type != typ {
+			t.Errorf("expected type %s, got %s", typ, got.Type)
+		}
+		if !reflect.DeepEqual(got.Meta, meta) {
+			t.Errorf("%s: metadata not equal", typ)
+		}
+	}
+}
+
+func TestTxPage_AddPending(t *testing.T) {
+	page := TxPage{
+		{ID: "old", Date: 1},
+		{ID: "new", Date: 2},
+	}
+	page.AddPending(TxPage{
+		{ID: "new", Date: 3, Status: StatusPending},
+		{ID: "pending", Status: StatusPending},
+	})
+	page.Sort()
+
+	var ids []string
+	for _, tx := range page {
+		ids = append(ids, tx.ID)
+	}
+	expected := []string{"pending", "new", "old"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestTxBatchPage_MarshalJSON(t *testing.T) {
+	tx := func(id string, date int64) Tx {
+		return Tx{ID: id, Date: date, Meta: Transfer{Value: "1"}}
+	}
+	var batch TxBatchPage
+	batch.Add(TxPage{tx("a", 1), tx("b", 2)})
+	batch.Add(TxPage{tx("b", 2), tx("c", 3)})
+	batch.AddError("bad", ErrSourceConn)
+	batch.Txs.Sort()
+
+	got, err := json.Marshal(&batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Total int `json:"total"`
+		Docs  []struct {
+			ID string `json:"id"`
+		} `json:"docs"`
+		Errors map[string]struct {
+			Kind      string `json:"kind"`
+			Retryable bool   `json:"retryable"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(got, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, tx := range res.Docs {
+		ids = append(ids, tx.ID)
+	}
+	expected := []string{"c", "b", "a"}
+	if res.Total != 3 || !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+	if len(res.Errors) != 1 || res.Errors["bad"].Kind == "" {
+		t.Errorf("expected error of address bad, got %v", res.Errors)
+	}
+}
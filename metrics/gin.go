@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Middleware observes the count and latency of requests
+// by route template and platform handle
+func Middleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	// Label unknown paths as one route to bound the cardinality
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	handle := routeHandle(c, route)
+	status := strconv.Itoa(c.Writer.Status())
+
+	HTTPRequests.WithLabelValues(route, handle, status).Inc()
+	HTTPDuration.WithLabelValues(route, handle).Observe(time.Since(start).Seconds())
+}
+
+// routeHandle returns the platform handle of a route
+//  - /v2/nimiq/:address => "nimiq"
+//  - /v2/:handle => value of the handle parameter
+//  - /v2/portfolio => "" (across platforms)
+func routeHandle(c *gin.Context, route string) string {
+	parts := strings.SplitN(route, "/", 4)
+	if len(parts) < 3 || parts[1] != "v2" {
+		return ""
+	}
+	if strings.HasPrefix(parts[2], ":") {
+		if c.Writer.Status() == http.StatusNotFound {
+			// Unknown platform, the parameter is arbitrary
+			return ""
+		}
+		return c.Param(parts[2][1:])
+	}
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[2]
+}
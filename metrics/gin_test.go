@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware)
+	engine.GET("/v2/nimiq/:address", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/v2/:handle", func(c *gin.Context) {
+		if c.Param("handle") != "nimiq" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/v2/portfolio", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/v2/nimiq/a", "/v2/nimiq/b", "/v2/nimiq", "/v2/unknown", "/v2/portfolio", "/nothing"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		route, handle, status string
+		want                  float64
+	}{
+		{"/v2/nimiq/:address", "nimiq", "200", 2},
+		{"/v2/:handle", "nimiq", "200", 1},
+		{"/v2/:handle", "", "404", 1},
+		{"/v2/portfolio", "", "200", 1},
+		{"unmatched", "", "404", 1},
+	}
+	for _, test := range tests {
+		got := testutil.ToFloat64(HTTPRequests.WithLabelValues(test.route, test.handle, test.status))
+		if got != test.want {
+			t.Errorf("requests of %s %q %s: expected %v, got %v",
+				test.route, test.handle, test.status, test.want, got)
+		}
+	}
+}
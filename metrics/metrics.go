@@ -0,0 +1,179 @@
+// Package metrics defines the Prometheus metrics of the API and the observer.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "blockatlas"
+
+// API
+var (
+	// HTTPRequests counts served requests by route, platform handle and status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests served by route, platform handle and status code.",
+	}, []string{"route", "handle", "status"})
+
+	// HTTPDuration observes the latency of served requests
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route and platform handle.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "handle"})
+)
+
+// Platforms
+var (
+	// UpstreamDuration observes the latency of calls to source APIs,
+	// including retries
+	UpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "upstream",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests to source APIs by platform handle.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handle"})
+
+	// UpstreamErrors counts failed calls to source APIs by reason
+	UpstreamErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "upstream",
+		Name:      "errors_total",
+		Help:      "Failed requests to source APIs by platform handle and reason.",
+	}, []string{"handle", "reason"})
+
+	// CacheLookups counts cached lookups by result (hit or miss),
+	// the hit ratio is hits / (hits + misses)
+	CacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Cached lookups by platform handle and result (hit or miss).",
+	}, []string{"handle", "result"})
+
+	// NormalizationDrops counts source transactions
+	// that could not be normalized and were skipped
+	NormalizationDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "platform",
+		Name:      "normalization_drops_total",
+		Help:      "Source transactions dropped by normalization by platform handle.",
+	}, []string{"handle"})
+)
+
+// Observer
+var (
+	// HeadHeight is the latest block number of a chain
+	HeadHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "head_height",
+		Help:      "Latest block number reported by the source API.",
+	}, []string{"handle"})
+
+	// TrackedHeight is the last block number processed by the observer
+	TrackedHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "tracked_height",
+		Help:      "Last block number known to the tracker.",
+	}, []string{"handle"})
+
+	// Lag is the number of blocks the observer is behind the chain head
+	Lag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "lag_blocks",
+		Help:      "Blocks between the chain head and the tracked height.",
+	}, []string{"handle"})
+
+	// BlocksFetched counts blocks loaded from source APIs
+	BlocksFetched = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "blocks_fetched_total",
+		Help:      "Blocks loaded from the source API.",
+	}, []string{"handle"})
+
+	// BlockErrors counts blocks that failed to load
+	BlockErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "block_errors_total",
+		Help:      "Blocks that failed to load from the source API.",
+	}, []string{"handle"})
+
+	// EventsEmitted counts transactions matching a subscription
+	EventsEmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "events_emitted_total",
+		Help:      "Transaction events emitted for subscriptions.",
+	}, []string{"handle"})
+
+	// Webhooks counts webhook dispatches by result (success or failure)
+	Webhooks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "webhooks_total",
+		Help:      "Webhook dispatches by platform handle and result.",
+	}, []string{"handle", "result"})
+
+	// WebhookDuration observes the latency of webhook dispatches
+	WebhookDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "observer",
+		Name:      "webhook_duration_seconds",
+		Help:      "Latency of webhook dispatches by platform handle.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handle"})
+)
+
+// Results of webhook dispatches
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Results of cached lookups
+const (
+	ResultHit  = "hit"
+	ResultMiss = "miss"
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequests,
+		HTTPDuration,
+		UpstreamDuration,
+		UpstreamErrors,
+		CacheLookups,
+		NormalizationDrops,
+		HeadHeight,
+		TrackedHeight,
+		Lag,
+		BlocksFetched,
+		BlockErrors,
+		EventsEmitted,
+		Webhooks,
+		WebhookDuration,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Dropped counts a source transaction of a platform
+// that could not be normalized
+func Dropped(handle string) {
+	NormalizationDrops.WithLabelValues(handle).Inc()
+}
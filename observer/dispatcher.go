@@ -3,8 +3,12 @@ package observer
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
 	"net/http"
+	"time"
 )
 
 type Dispatcher struct {
@@ -30,10 +34,22 @@ func (d *Dispatcher) dispatch(event Event) {
 		"txID": event.Tx.ID,
 	})
 
-	_, err = d.Client.Post(webhook, "application/json", bytes.NewReader(txJson))
+	handle := coin.Coins[event.Subscription.Coin].Handle
+	start := time.Now()
+	res, err := d.Client.Post(webhook, "application/json", bytes.NewReader(txJson))
+	metrics.WebhookDuration.WithLabelValues(handle).Observe(time.Since(start).Seconds())
+	if err == nil {
+		_ = res.Body.Close()
+		if res.StatusCode >= http.StatusBadRequest {
+			err = fmt.Errorf("webhook returned status %d", res.StatusCode)
+		}
+	}
 	if err != nil {
+		metrics.Webhooks.WithLabelValues(handle, metrics.ResultFailure).Inc()
 		log.WithError(err).Errorf("Failed to dispatch event %s: %s", webhook, err)
+		return
 	}
+	metrics.Webhooks.WithLabelValues(handle, metrics.ResultSuccess).Inc()
 
 	log.Debug("Dispatch")
 }
@@ -3,6 +3,8 @@ package observer
 import (
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
 )
 
 type Event struct {
@@ -13,6 +15,9 @@ type Event struct {
 type Observer struct {
 	Storage Storage
 	Coin uint
+	// Converts addresses of transactions into the canonical
+	// form of subscriptions (optional)
+	Validator blockatlas.AddressValidator
 }
 
 func (o *Observer) Execute(blocks <-chan *blockatlas.Block) <-chan Event {
@@ -28,14 +33,23 @@ func (o *Observer) run(events chan<- Event, blocks <-chan *blockatlas.Block) {
 	for block := range blocks {
 		o.processBlock(events, block)
 	}
+	// The stream stopped, no more events
+	close(events)
 }
 
 func (o *Observer) processBlock(events chan<- Event, block *blockatlas.Block) {
-	// Order transactions in block by addresses
+	// Order transactions in block by the addresses involved
 	txMap := make(map[string][]*blockatlas.Tx)
-	for _, tx := range block.Txs {
-		txMap[tx.From] = append(txMap[tx.From], &tx)
-		txMap[tx.To] = append(txMap[tx.To], &tx)
+	for i := range block.Txs {
+		tx := &block.Txs[i]
+		seen := make(map[string]bool)
+		for _, participant := range tx.Participants() {
+			address := o.canonical(participant)
+			if !seen[address] {
+				seen[address] = true
+				txMap[address] = append(txMap[address], tx)
+			}
+		}
 	}
 
 	// Build list of unique addresses
@@ -52,13 +66,24 @@ func (o *Observer) processBlock(events chan<- Event, block *blockatlas.Block) {
 	}
 
 	// Emit events
+	handle := coin.Coins[o.Coin].Handle
 	for _, sub := range subs {
 		txs := txMap[sub.Address]
 		for _, tx := range txs {
+			// Copy the transaction, the direction depends on the subscription
+			subTx := *tx
+			subTx.Direction = subTx.DirectionOf(sub.Address, o.canonical)
 			events <- Event{
 				Subscription: sub,
-				Tx: tx,
+				Tx: &subTx,
 			}
+			metrics.EventsEmitted.WithLabelValues(handle).Inc()
 		}
 	}
 }
+
+// canonical returns the canonical form of an address,
+// addresses the validator rejects are kept as is
+func (o *Observer) canonical(address string) string {
+	return blockatlas.CanonicalAddress(o.Validator, address)
+}
@@ -0,0 +1,63 @@
+package observer
+
+import (
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"testing"
+)
+
+// subscriptions looks up fixed subscriptions
+type subscriptions struct {
+	tracker
+	subs []Subscription
+}
+
+func (s *subscriptions) Lookup(_ uint, addresses ...string) ([]Subscription, error) {
+	var found []Subscription
+	for _, sub := range s.subs {
+		for _, address := range addresses {
+			if sub.Address == address {
+				found = append(found, sub)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *subscriptions) Add([]Subscription) error    { return nil }
+func (s *subscriptions) Delete([]Subscription) error { return nil }
+
+func TestObserver_ProcessBlock(t *testing.T) {
+	o := &Observer{
+		Coin: coin.NIM,
+		Storage: &subscriptions{subs: []Subscription{
+			{Coin: coin.NIM, Address: "recipient", Webhook: "recipient"},
+			{Coin: coin.NIM, Address: "self", Webhook: "self"},
+		}},
+	}
+	block := &blockatlas.Block{Txs: []blockatlas.Tx{
+		{ID: "token", From: "sender", To: "contract", Meta: blockatlas.TokenTransfer{From: "sender", To: "recipient"}},
+		{ID: "self", From: "self", To: "self", Meta: blockatlas.Transfer{}},
+		{ID: "other", From: "sender", To: "contract", Meta: blockatlas.Transfer{}},
+	}}
+
+	events := make(chan Event, 10)
+	o.processBlock(events, block)
+	close(events)
+
+	got := make(map[string]string)
+	count := 0
+	for event := range events {
+		got[event.Subscription.Address] = event.Tx.ID + " " + event.Tx.Direction
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected 2 events, got %d: %v", count, got)
+	}
+	if got["recipient"] != "token incoming" {
+		t.Errorf("expected incoming token transfer, got %q", got["recipient"])
+	}
+	if got["self"] != "self self" {
+		t.Errorf("expected transfer to self, got %q", got["self"])
+	}
+}
@@ -3,7 +3,6 @@ package memory
 import (
 	"fmt"
 	"github.com/trustwallet/blockatlas/observer"
-	"strings"
 )
 
 type Storage struct {
@@ -63,6 +62,8 @@ func (s *Storage) SetBlockNumber(coin uint, num int64) error {
 	return nil
 }
 
+// key matches the Redis storage, addresses are expected
+// to be in the canonical form of their platform
 func key(coin uint, address string) string {
-	return fmt.Sprintf("%d-%s", coin, strings.ToUpper(address))
+	return fmt.Sprintf("%d-%s", coin, address)
 }
@@ -10,17 +10,37 @@ import (
 
 var App observer.Storage
 
+// client is the Redis client of App
+var client *redis.Client
+
 func Load() {
+	if viper.GetString("observer.auth") == "" {
+		logrus.Fatal("Refusing to run observer API without a password")
+	}
+	App = sredis.New(Redis())
+}
+
+// Redis returns the Redis client configured by observer.redis,
+// connecting on first use
+func Redis() *redis.Client {
+	if client != nil {
+		return client
+	}
 	options, err := redis.ParseURL(viper.GetString("observer.redis"))
 	if err != nil {
 		logrus.WithError(err).Fatal("Cannot connect to Redis")
 	}
-	client := redis.NewClient(options)
+	client = redis.NewClient(options)
 	if err := client.Ping().Err(); err != nil {
 		logrus.WithError(err).Fatal("Redis connection test failed")
 	}
-	if viper.GetString("observer.auth") == "" {
-		logrus.Fatal("Refusing to run observer API without a password")
+	return client
+}
+
+// Ping checks the connection to Redis if it is in use
+func Ping() error {
+	if client == nil {
+		return nil
 	}
-	App = sredis.New(client)
+	return client.Ping().Err()
 }
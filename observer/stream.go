@@ -5,29 +5,35 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/metrics"
 	"github.com/trustwallet/blockatlas/util"
 	"sync"
-	"sync/atomic"
 	"time"
 )
 
 type Stream struct {
-	BlockAPI     blockatlas.BlockAPI
+	BlockAPI     blockatlas.BlockContextAPI
 	Tracker      Tracker
 	PollInterval time.Duration
 	BacklogCount int
 	coin         uint
+	handle       string
 	log          *logrus.Entry
 
 	// Concurrency
-	blockNumber  int64
 	semaphore    *util.Semaphore
 	wg           sync.WaitGroup
+
+	// Progress of the current poll, blocks load out of order
+	mu           sync.Mutex
+	blockNumber  int64          // highest block delivered with all blocks before it
+	delivered    map[int64]bool // delivered blocks after blockNumber
 }
 
 func (s *Stream) Execute(ctx context.Context) <-chan *blockatlas.Block {
 	cn := s.BlockAPI.Coin()
 	s.coin = cn.ID
+	s.handle = cn.Handle
 	s.log = logrus.WithField("platform", cn.Handle)
 	conns := viper.GetInt("observer.stream_conns")
 	if conns == 0 {
@@ -45,26 +51,30 @@ func (s *Stream) run(ctx context.Context, c chan<- *blockatlas.Block) {
 		select {
 		case <-ctx.Done():
 			ticker.Stop()
+			s.persist()
 			close(c)
 			return
 		case <-ticker.C:
-			s.load(c)
+			s.load(ctx, c)
 		}
 	}
 }
 
-func (s *Stream) load(c chan<- *blockatlas.Block) {
+func (s *Stream) load(ctx context.Context, c chan<- *blockatlas.Block) {
 	lastHeight, err := s.Tracker.GetBlockNumber(s.coin)
 	if err != nil {
 		s.log.WithError(err).Error("Polling failed: tracker didn't return last known block number")
 		return
 	}
 
-	height, err := s.BlockAPI.CurrentBlockNumber()
+	height, err := s.BlockAPI.CurrentBlockNumberContext(ctx)
 	if err != nil {
 		s.log.WithError(err).Error("Polling failed: source didn't return chain head number")
 		return
 	}
+	metrics.HeadHeight.WithLabelValues(s.handle).Set(float64(height))
+	metrics.TrackedHeight.WithLabelValues(s.handle).Set(float64(lastHeight))
+	metrics.Lag.WithLabelValues(s.handle).Set(float64(height - lastHeight))
 
 	if height - lastHeight > int64(s.BacklogCount) {
 		lastHeight = height - int64(s.BacklogCount)
@@ -74,33 +84,73 @@ func (s *Stream) load(c chan<- *blockatlas.Block) {
 		lastHeight = height - backLogMax
 	}
 
-	atomic.StoreInt64(&s.blockNumber, lastHeight)
+	s.mu.Lock()
+	s.blockNumber = lastHeight
+	s.delivered = make(map[int64]bool)
+	s.mu.Unlock()
 	for i := lastHeight + 1; i <= height; i++ {
 		s.wg.Add(1)
-		go s.loadBlock(c, i)
+		go s.loadBlock(ctx, c, i)
 	}
 	s.wg.Wait()
 }
 
-func (s *Stream) loadBlock(c chan<- *blockatlas.Block, num int64) {
+func (s *Stream) loadBlock(ctx context.Context, c chan<- *blockatlas.Block, num int64) {
 	defer s.wg.Done()
 	s.semaphore.Acquire()
 	defer s.semaphore.Release()
 
-	block, err := s.BlockAPI.GetBlockByNumber(num)
+	block, err := s.BlockAPI.GetBlockByNumberContext(ctx, num)
+	if ctx.Err() != nil {
+		// Stopped
+		return
+	}
 	if err != nil {
+		metrics.BlockErrors.WithLabelValues(s.handle).Inc()
 		s.log.WithError(err).Errorf("Polling failed: could not get block %d", num)
 		return
 	}
+	metrics.BlocksFetched.WithLabelValues(s.handle).Inc()
 	c <- block
 	s.log.WithField("num", num).Info("Got new block")
+	s.complete(num)
+}
 
-	// Not strictly correct nor avoids race conditions
-	// But good enough
-	newNum := atomic.AddInt64(&s.blockNumber, 1)
-	err = s.Tracker.SetBlockNumber(s.coin, newNum)
+// complete marks a block as delivered and saves the highest block number
+// all blocks up to which have been delivered. Blocks that failed or were
+// canceled hold the number back, they are loaded again on the next poll.
+func (s *Stream) complete(num int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delivered[num] = true
+	advanced := false
+	for s.delivered[s.blockNumber+1] {
+		delete(s.delivered, s.blockNumber+1)
+		s.blockNumber++
+		advanced = true
+	}
+	if !advanced {
+		return
+	}
+	// Saved under the lock to keep updates in order
+	err := s.Tracker.SetBlockNumber(s.coin, s.blockNumber)
 	if err != nil {
 		s.log.WithError(err).Error("Polling failed: could not update block number at tracker")
+	}
+}
+
+// persist saves the number of the last block
+// delivered with all blocks before it once all loads are done
+func (s *Stream) persist() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blockNumber == 0 {
+		// Nothing loaded
+		return
+	}
+	if err := s.Tracker.SetBlockNumber(s.coin, s.blockNumber); err != nil {
+		s.log.WithError(err).Error("Could not save block number at tracker")
 		return
 	}
+	s.log.WithField("num", s.blockNumber).Info("Saved block number")
 }
@@ -0,0 +1,103 @@
+package observer
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"sync"
+	"testing"
+	"time"
+)
+
+type chain struct {
+	height int64
+	// Loads of this block hang until canceled (optional)
+	stuck int64
+}
+
+func (c *chain) Init() error     { return nil }
+func (c *chain) Coin() coin.Coin { return coin.Coins[coin.NIM] }
+func (c *chain) CurrentBlockNumberContext(ctx context.Context) (int64, error) {
+	return c.height, nil
+}
+func (c *chain) GetBlockByNumberContext(ctx context.Context, num int64) (*blockatlas.Block, error) {
+	if num == c.stuck {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &blockatlas.Block{Number: num}, nil
+}
+
+type tracker struct {
+	sync.Mutex
+	num int64
+}
+
+func (t *tracker) GetBlockNumber(uint) (int64, error) {
+	t.Lock()
+	defer t.Unlock()
+	return t.num, nil
+}
+
+func (t *tracker) SetBlockNumber(_ uint, num int64) error {
+	t.Lock()
+	defer t.Unlock()
+	t.num = num
+	return nil
+}
+
+func TestStream_Shutdown(t *testing.T) {
+	viper.Set("observer.stream_conns", 4)
+	viper.Set("observer.backlog_max_blocks", 100)
+
+	tr := &tracker{num: 10}
+	stream := Stream{
+		BlockAPI:     &chain{height: 15},
+		Tracker:      tr,
+		PollInterval: time.Millisecond,
+		BacklogCount: 100,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks := stream.Execute(ctx)
+
+	// Receive the 5 new blocks, then stop
+	for i := 0; i < 5; i++ {
+		<-blocks
+	}
+	cancel()
+	for range blocks {
+	}
+
+	if num, _ := tr.GetBlockNumber(coin.NIM); num != 15 {
+		t.Errorf("expected tracked block number 15, got %d", num)
+	}
+}
+
+func TestStream_ShutdownGap(t *testing.T) {
+	viper.Set("observer.stream_conns", 4)
+	viper.Set("observer.backlog_max_blocks", 100)
+
+	tr := &tracker{num: 10}
+	stream := Stream{
+		BlockAPI:     &chain{height: 15, stuck: 12},
+		Tracker:      tr,
+		PollInterval: time.Millisecond,
+		BacklogCount: 100,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks := stream.Execute(ctx)
+
+	// Receive all blocks but 12, then stop while it is loading
+	for i := 0; i < 4; i++ {
+		<-blocks
+	}
+	cancel()
+	for range blocks {
+	}
+
+	// Block 12 was never delivered, it has to be loaded again
+	if num, _ := tr.GetBlockNumber(coin.NIM); num != 11 {
+		t.Errorf("expected tracked block number 11, got %d", num)
+	}
+}
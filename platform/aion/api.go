@@ -1,12 +1,11 @@
 package aion
 
 import (
-	"github.com/spf13/viper"
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"github.com/trustwallet/blockatlas/util"
-	"net/http"
-	"strconv"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 )
 
 type Platform struct {
@@ -14,8 +13,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("aion.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -24,19 +23,38 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	if srcTxs, err := p.client.GetTxsOfAddress(address, blockatlas.TxPerPage); err == nil {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	if srcTxs, err := p.client.GetTxsOfAddress(ctx, address, blockatlas.TxPerPage); err == nil {
 		return NormalizeTxs(srcTxs.Content), err
 	} else {
 		return nil, err
 	}
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	accountPage, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if len(accountPage.Content) == 0 {
+		return "0", nil
+	}
+	balance := accountPage.Content[0].Balance
+	return blockatlas.ParseAmount(string(balance), p.Coin().Decimals)
+}
+
 // NormalizeTx converts an Aion transaction into the generic model
 func NormalizeTx(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
-	fee := strconv.Itoa(srcTx.NrgConsumed)
-	value := util.DecimalExp(string(srcTx.Value), 18)
-	value, ok = util.CutZeroFractional(value)
-	if !ok {
+	fee := blockatlas.AmountFromUint64(uint64(srcTx.NrgConsumed))
+	value, err := blockatlas.ParseAmount(string(srcTx.Value), coin.Coins[coin.AION].Decimals)
+	if err != nil {
 		return tx, false
 	}
 
@@ -46,10 +64,10 @@ func NormalizeTx(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 		Date:  srcTx.BlockTimestamp,
 		From:  "0x" + srcTx.FromAddr,
 		To:    "0x" + srcTx.ToAddr,
-		Fee:   blockatlas.Amount(fee),
+		Fee:   fee,
 		Block: srcTx.BlockNumber,
 		Meta:  blockatlas.Transfer{
-			Value: blockatlas.Amount(value),
+			Value: value,
 		},
 	}, true
 }
@@ -59,9 +77,11 @@ func NormalizeTxs(srcTxs []Tx) []blockatlas.Tx {
 	var txs []blockatlas.Tx
 	for _, srcTx := range srcTxs {
 		tx, ok := NormalizeTx(&srcTx)
-		if ok {
-			txs = append(txs, tx)
+		if !ok {
+			metrics.Dropped(coin.Coins[coin.AION].Handle)
+			continue
 		}
+		txs = append(txs, tx)
 	}
 	return txs
 }
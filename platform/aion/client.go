@@ -1,9 +1,11 @@
 package aion
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -14,7 +16,7 @@ type Client struct {
 	BaseURL    string
 }
 
-func (c *Client) GetTxsOfAddress(address string, num int) (*TxPage, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, num int) (*TxPage, error) {
 	uri := fmt.Sprintf("%s/getTransactionsByAddress?%s",
 		c.BaseURL,
 		url.Values{
@@ -22,7 +24,7 @@ func (c *Client) GetTxsOfAddress(address string, num int) (*TxPage, error) {
 			"size":           {strconv.Itoa(num)},
 		}.Encode())
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Errorf("Aion: Failed to get transactions for address %s", address)
 	}
@@ -33,3 +35,22 @@ func (c *Client) GetTxsOfAddress(address string, num int) (*TxPage, error) {
 	return txPage, err
 }
 
+
+func (c *Client) GetAccount(ctx context.Context, address string) (*AccountPage, error) {
+	uri := fmt.Sprintf("%s/getAccountDetails?%s",
+		c.BaseURL,
+		url.Values{
+			"accountAddress": {address},
+		}.Encode())
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Errorf("Aion: Failed to get account %s", address)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	accountPage := new(AccountPage)
+	err = json.NewDecoder(res.Body).Decode(accountPage)
+	return accountPage, err
+}
@@ -6,6 +6,16 @@ type TxPage struct {
 	Content []Tx
 }
 
+type AccountPage struct {
+	Content []Account
+}
+
+type Account struct {
+	Address string      `json:"address"`
+	Balance json.Number `json:"balance"`
+	Nonce   string      `json:"nonce"`
+}
+
 type Tx struct {
 	BlockHash            string      `json:"blockHash"`
 	ToAddr               string      `json:"toAddr"`
@@ -1,12 +1,15 @@
 package binance
 
 import (
+	"context"
 	"fmt"
 	"github.com/trustwallet/blockatlas"
-	"net/http"
+	"strconv"
+	"strings"
 
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/trustwallet/blockatlas/util"
 )
 
@@ -15,8 +18,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("binance.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -24,11 +27,25 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[coin.BNB]
 }
 
+// ValidateAddress checks the Bech32 encoding
+// of an account address ("bnb1...")
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	canonical, hrp, data, err := util.Bech32Decode(address)
+	if err != nil || hrp != "bnb" || len(data) != 20 {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return canonical, nil
+}
+
 func (p *Platform) CurrentBlockNumber() (int64, error) {
+	return p.CurrentBlockNumberContext(context.Background())
+}
+
+func (p *Platform) CurrentBlockNumberContext(ctx context.Context) (int64, error) {
 	// No native function to get height in explorer API
 	// Workaround: Request list of blocks
 	// and return number of the newest one
-	list, err := p.client.GetBlockList(1)
+	list, err := p.client.GetBlockList(ctx, 1)
 	if err != nil {
 		return 0, err
 	}
@@ -39,7 +56,11 @@ func (p *Platform) CurrentBlockNumber() (int64, error) {
 }
 
 func (p *Platform) GetBlockByNumber(num int64) (*blockatlas.Block, error) {
-	srcTxs, err := p.client.GetBlockByNumber(num)
+	return p.GetBlockByNumberContext(context.Background(), num)
+}
+
+func (p *Platform) GetBlockByNumberContext(ctx context.Context, num int64) (*blockatlas.Block, error) {
+	srcTxs, err := p.client.GetBlockByNumber(ctx, num)
 	if err != nil {
 		return nil, err
 	}
@@ -52,22 +73,157 @@ func (p *Platform) GetBlockByNumber(num int64) (*blockatlas.Block, error) {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
 	// Endpoint supports queries without token query parameter
-	return p.GetTokenTxsByAddress(address, "")
+	return p.GetTokenTxsByAddressContext(ctx, address, "")
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
+
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The cursor is the number of the explorer page.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	page := 1
+	if cursor != "" {
+		var err error
+		page, err = strconv.Atoi(cursor)
+		if err != nil || page < 1 {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
+	}
+
+	srcTxs, err := p.client.GetTxsOfAddress(ctx, address, "", page, blockatlas.TxPerPage)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if page*blockatlas.TxPerPage < srcTxs.Nums {
+		next = strconv.Itoa(page + 1)
+	}
+
+	return NormalizeTxs(srcTxs.Txs, ""), next, nil
 }
 
 func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
-	srcTxs, err := p.client.GetTxsOfAddress(address, token)
+	return p.GetTokenTxsByAddressContext(context.Background(), address, token)
+}
+
+func (p *Platform) GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (blockatlas.TxPage, error) {
+	srcTxs, err := p.client.GetTxsOfAddress(ctx, address, token, 1, 100)
 	if err != nil {
 		return nil, err
 	}
 	return NormalizeTxs(srcTxs.Txs, token), nil
 }
 
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	srcTx, err := p.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Token transfers only normalize when asked for their asset
+	var token string
+	if srcTx.Asset != "BNB" {
+		token = srcTx.Asset
+	}
+	tx, ok := NormalizeTx(srcTx, token)
+	if !ok {
+		return nil, blockatlas.ErrNotFound
+	}
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if account == nil {
+		return "0", nil
+	}
+
+	for _, balance := range account.Balances {
+		if balance.Symbol != "BNB" {
+			continue
+		}
+		return blockatlas.ParseAmount(string(balance.Free), 8)
+	}
+
+	return "0", nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, nil
+	}
+
+	var tokens []blockatlas.Token
+	for _, balance := range account.Balances {
+		if balance.Symbol == "BNB" {
+			continue
+		}
+		if token, ok := NormalizeToken(&balance); ok {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// NormalizeToken converts a Binance asset balance into the generic model
+func NormalizeToken(srcBalance *Balance) (token blockatlas.Token, ok bool) {
+	value, err := blockatlas.ParseAmount(string(srcBalance.Free), 8)
+	if err != nil {
+		return token, false
+	}
+
+	// Asset names are made of the symbol and a suffix (e.g. "YLC-D8B")
+	symbol := srcBalance.Symbol
+	if i := strings.IndexRune(symbol, '-'); i != -1 {
+		symbol = symbol[:i]
+	}
+
+	return blockatlas.Token{
+		Name:     symbol,
+		Symbol:   symbol,
+		TokenID:  srcBalance.Symbol,
+		Decimals: 8,
+		Balance:  value,
+	}, true
+}
+
 // NormalizeTx converts a Binance transaction into the generic model
 func NormalizeTx(srcTx *Tx, token string) (tx blockatlas.Tx, ok bool) {
-	value := util.DecimalExp(string(srcTx.Value), 8)
-	fee := util.DecimalExp(string(srcTx.Fee), 8)
+	value, err := blockatlas.ParseAmount(string(srcTx.Value), 8)
+	if err != nil {
+		return tx, false
+	}
+	fee, err := blockatlas.ParseAmount(string(srcTx.Fee), 8)
+	if err != nil {
+		return tx, false
+	}
 
 	tx = blockatlas.Tx{
 		ID:    srcTx.Hash,
@@ -75,7 +231,7 @@ func NormalizeTx(srcTx *Tx, token string) (tx blockatlas.Tx, ok bool) {
 		Date:  srcTx.Timestamp / 1000,
 		From:  srcTx.FromAddr,
 		To:    srcTx.ToAddr,
-		Fee:   blockatlas.Amount(fee),
+		Fee:   fee,
 		Block: srcTx.BlockHeight,
 		Memo:  srcTx.Memo,
 	}
@@ -83,7 +239,7 @@ func NormalizeTx(srcTx *Tx, token string) (tx blockatlas.Tx, ok bool) {
 	// Condition for native transfer (BNB)
 	if srcTx.Asset == "BNB" && srcTx.Type == "TRANSFER" && token == "" {
 		tx.Meta = blockatlas.Transfer{
-			Value: blockatlas.Amount(value),
+			Value: value,
 		}
 		return tx, true
 	}
@@ -93,7 +249,7 @@ func NormalizeTx(srcTx *Tx, token string) (tx blockatlas.Tx, ok bool) {
 		tx.Meta = blockatlas.NativeTokenTransfer{
 			TokenID:  srcTx.Asset,
 			Symbol:   srcTx.MappedAsset,
-			Value:    blockatlas.Amount(value),
+			Value:    value,
 			Decimals: 8,
 			From:     srcTx.FromAddr,
 			To:       srcTx.ToAddr,
@@ -109,7 +265,11 @@ func NormalizeTx(srcTx *Tx, token string) (tx blockatlas.Tx, ok bool) {
 func NormalizeTxs(srcTxs []Tx, token string) (txs []blockatlas.Tx) {
 	for _, srcTx := range srcTxs {
 		tx, ok := NormalizeTx(&srcTx, token)
-		if !ok || len(txs) >= blockatlas.TxPerPage {
+		if !ok {
+			metrics.Dropped(coin.Coins[coin.BNB].Handle)
+			continue
+		}
+		if len(txs) >= blockatlas.TxPerPage {
 			continue
 		}
 		txs = append(txs, tx)
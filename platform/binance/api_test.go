@@ -2,8 +2,13 @@ package binance
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/trustwallet/blockatlas/coin"
@@ -135,3 +140,81 @@ func testNormalizeTx(t *testing.T, _test *test) {
 		t.Error("transfer: tx don't equal")
 	}
 }
+
+const tokenBalance = `
+{
+	"symbol": "YLC-D8B",
+	"free": "2.10572645",
+	"locked": "0.00000000",
+	"frozen": "0.00000000"
+}`
+
+var tokenDst = blockatlas.Token{
+	Name:     "YLC",
+	Symbol:   "YLC",
+	TokenID:  "YLC-D8B",
+	Decimals: 8,
+	Balance:  "210572645",
+}
+
+func TestNormalizeToken(t *testing.T) {
+	var srcBalance Balance
+	err := json.Unmarshal([]byte(tokenBalance), &srcBalance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, ok := NormalizeToken(&srcBalance)
+	if !ok {
+		t.Fatal("token could not be normalized")
+	}
+
+	if !reflect.DeepEqual(token, tokenDst) {
+		t.Errorf("token don't equal: %+v", token)
+	}
+}
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	// The explorer has 60 transactions of the address
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/txs" || r.URL.Query().Get("rows") != "25" {
+			http.NotFound(w, r)
+			return
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `{"txNums": 60, "txArray": [%s]}`, nativeTransferTransaction)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tests := []struct {
+		cursor string
+		page   string
+		next   string
+	}{
+		{"", "1", "2"},
+		{"2", "2", "3"},
+		{"3", "3", ""},
+	}
+	for _, test := range tests {
+		pages = nil
+		txs, next, err := p.GetTxPageByAddressContext(context.Background(), transferDst.From, test.cursor)
+		if err != nil {
+			t.Fatalf("cursor %q: %v", test.cursor, err)
+		}
+		if len(pages) != 1 || pages[0] != test.page || len(txs) != 1 {
+			t.Errorf("cursor %q: expected page %s, got %v", test.cursor, test.page, pages)
+		}
+		if next != test.next {
+			t.Errorf("cursor %q: expected next %q, got %q", test.cursor, test.next, next)
+		}
+	}
+
+	for _, cursor := range []string{"next", "0", "-1"} {
+		_, _, err := p.GetTxPageByAddressContext(context.Background(), transferDst.From, cursor)
+		if err != blockatlas.ErrInvalidCursor {
+			t.Errorf("cursor %q: expected invalid cursor, got %v", cursor, err)
+		}
+	}
+}
@@ -1,27 +1,28 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
 )
 
-// TODO Headers + rate limiting
 
 type Client struct {
 	HTTPClient *http.Client
 	BaseURL    string
 }
 
-func (c *Client) GetBlockList(count int) (*BlockList, error) {
+func (c *Client) GetBlockList(ctx context.Context, count int) (*BlockList, error) {
 	uri := fmt.Sprintf("%s/blocks?page=1&rows=%d",
 		c.BaseURL, count)
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -33,13 +34,13 @@ func (c *Client) GetBlockList(count int) (*BlockList, error) {
 	var blockList BlockList
 	err = json.NewDecoder(res.Body).Decode(&blockList)
 	if err != nil {
-		return nil, err
+		return nil, blockatlas.ErrDecode(err)
 	} else {
 		return &blockList, nil
 	}
 }
 
-func (c *Client) GetBlockByNumber(num int64) (*TxPage, error) {
+func (c *Client) GetBlockByNumber(ctx context.Context, num int64) (*TxPage, error) {
 	uri := fmt.Sprintf("%s/txs?%s",
 		c.BaseURL,
 		url.Values{
@@ -50,7 +51,7 @@ func (c *Client) GetBlockByNumber(num int64) (*TxPage, error) {
 			"page":        {"1"},
 		}.Encode())
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -64,20 +65,21 @@ func (c *Client) GetBlockByNumber(num int64) (*TxPage, error) {
 	return stx, nil
 }
 
-func (c *Client) GetTxsOfAddress(address string, token string) (*TxPage, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, token string, page int, rows int) (*TxPage, error) {
 	uri := fmt.Sprintf("%s/txs?%s",
 		c.BaseURL,
 		url.Values{
 			"address": {address},
-			"rows":    {"100"},
-			"page":    {"1"},
+			"rows":    {strconv.Itoa(rows)},
+			"page":    {strconv.Itoa(page)},
 		}.Encode())
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Error("Binance: Failed to get transactions")
 		return nil, blockatlas.ErrSourceConn
 	}
+	defer res.Body.Close()
 
 	if err := getHTTPError(res, "GetTxsOfAddress"); err != nil {
 		return nil, err
@@ -88,6 +90,67 @@ func (c *Client) GetTxsOfAddress(address string, token string) (*TxPage, error)
 	return stx, nil
 }
 
+func (c *Client) GetTx(ctx context.Context, hash string) (*Tx, error) {
+	uri := fmt.Sprintf("%s/tx?%s",
+		c.BaseURL,
+		url.Values{
+			"txHash": {hash},
+		}.Encode())
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Binance: Failed to get transaction")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, blockatlas.ErrNotFound
+	}
+	if err := getHTTPError(res, "GetTx"); err != nil {
+		return nil, err
+	}
+
+	stx := new(Tx)
+	err = json.NewDecoder(res.Body).Decode(stx)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+	if stx.Hash == "" {
+		return nil, blockatlas.ErrNotFound
+	}
+	return stx, nil
+}
+
+// GetAccount returns the account of an address
+// or nil if the account has not been created yet.
+func (c *Client) GetAccount(ctx context.Context, address string) (*Account, error) {
+	uri := fmt.Sprintf("%s/account/%s",
+		c.BaseURL,
+		url.PathEscape(address))
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Binance: Failed to get account")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := getHTTPError(res, "GetAccount"); err != nil {
+		return nil, err
+	}
+
+	account := new(Account)
+	err = json.NewDecoder(res.Body).Decode(account)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+	return account, nil
+}
+
 func getHTTPError(res *http.Response, desc string) error {
 	switch res.StatusCode {
 	case http.StatusBadRequest, http.StatusNotFound:
@@ -95,7 +158,7 @@ func getHTTPError(res *http.Response, desc string) error {
 	case http.StatusOK:
 		return nil
 	default:
-		return fmt.Errorf("%s", res.Status)
+		return blockatlas.ErrStatus(res.StatusCode)
 	}
 }
 
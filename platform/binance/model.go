@@ -14,10 +14,10 @@ type Account struct {
 }
 
 type Balance struct {
-	Symbol string `json:"symbol"`
-	Free   uint64 `json:"free"`
-	Locked uint64 `json:"locked"`
-	Frozen uint64 `json:"frozen"`
+	Symbol string      `json:"symbol"`
+	Free   json.Number `json:"free"`
+	Locked json.Number `json:"locked"`
+	Frozen json.Number `json:"frozen"`
 }
 
 type Error struct {
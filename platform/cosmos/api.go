@@ -1,13 +1,14 @@
 package cosmos
 
 import (
+	"context"
 	"github.com/trustwallet/blockatlas"
-	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/trustwallet/blockatlas/util"
 )
 
@@ -16,8 +17,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("cosmos.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -25,47 +26,184 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[coin.ATOM]
 }
 
+// ValidateAddress checks the Bech32 encoding
+// of an account address ("cosmos1...")
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	canonical, hrp, data, err := util.Bech32Decode(address)
+	if err != nil || hrp != "cosmos" || len(data) != 20 {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return canonical, nil
+}
+
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	inputTxes, _ := p.client.GetAddrTxes(address, "inputs")
-	outputTxes, _ := p.client.GetAddrTxes(address, "outputs")
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	// A failed lookup fails the page, a partial page would be cached
+	inputTxes, err := p.client.GetAddrTxes(ctx, address, "inputs")
+	if err != nil {
+		return nil, err
+	}
+	outputTxes, err := p.client.GetAddrTxes(ctx, address, "outputs")
+	if err != nil {
+		return nil, err
+	}
+	delegationTxes, err := p.client.GetAddrTxes(ctx, address, "delegations")
+	if err != nil {
+		return nil, err
+	}
 
 	normalisedTxes := make([]blockatlas.Tx, 0)
 
 	for _, inputTx := range inputTxes {
 		normalisedInputTx := Normalize(&inputTx)
+		if normalisedInputTx.Meta == nil {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
 		normalisedTxes = append(normalisedTxes, normalisedInputTx)
 	}
 	for _, outputTx := range outputTxes {
 		normalisedOutputTx := Normalize(&outputTx)
+		if normalisedOutputTx.Meta == nil {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
 		normalisedTxes = append(normalisedTxes, normalisedOutputTx)
 	}
+	for _, delegationTx := range delegationTxes {
+		normalisedDelegationTx := Normalize(&delegationTx)
+		if normalisedDelegationTx.Meta == nil {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
+		normalisedTxes = append(normalisedTxes, normalisedDelegationTx)
+	}
 
 	return normalisedTxes, nil
 }
 
-// Normalize converts an Cosmos transaction into the generic model
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	srcTx, err := p.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only transfers can be normalized
+	tx := Normalize(srcTx)
+	if tx.Meta == nil {
+		return nil, blockatlas.ErrNotFound
+	}
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	balances, err := p.client.GetBalances(ctx, address)
+	if err != nil {
+		return "", err
+	}
+
+	for _, balance := range balances {
+		if balance.Denom == "uatom" {
+			return blockatlas.ParseAmount(balance.Quantity, 0)
+		}
+	}
+
+	return "0", nil
+}
+
+// Normalize converts an Cosmos transaction into the generic model.
+// Every message becomes an operation of the transaction.
 func Normalize(srcTx *Tx) (tx blockatlas.Tx) {
 	date, _ := time.Parse("2006-01-02T15:04:05Z", srcTx.Date)
-	value, _ := util.DecimalToSatoshis(srcTx.Data.Contents.Message[0].Particulars.Amount[0].Quantity)
 	block, _ := strconv.ParseUint(srcTx.Block, 10, 64)
 	// Sometimes fees can be null objects (in the case of no fees e.g. F044F91441C460EDCD90E0063A65356676B7B20684D94C731CF4FAB204035B41)
-	var fee string
-	if len(srcTx.Data.Contents.Fee.FeeAmount) == 0 {
-		fee = "0"
-	} else {
-		fee, _ = util.DecimalToSatoshis(srcTx.Data.Contents.Fee.FeeAmount[0].Quantity)
+	var fee blockatlas.Amount = "0"
+	if len(srcTx.Data.Contents.Fee.FeeAmount) != 0 {
+		fee, _ = blockatlas.ParseAmount(srcTx.Data.Contents.Fee.FeeAmount[0].Quantity, 0)
 	}
-	return blockatlas.Tx{
+	tx = blockatlas.Tx{
 		ID:    srcTx.ID,
 		Coin:  coin.ATOM,
 		Date:  date.Unix(),
-		From:  srcTx.Data.Contents.Message[0].Particulars.FromAddr, // This will need to be adjusted for multi-outputs, later
-		To:    srcTx.Data.Contents.Message[0].Particulars.ToAddr,   // Likewise
-		Fee:   blockatlas.Amount(fee),
+		Fee:   fee,
 		Block: block,
 		Memo:  srcTx.Data.Contents.Memo,
-		Meta: blockatlas.Transfer{
-			Value: blockatlas.Amount(value),
-		},
+	}
+
+	var ops []blockatlas.Op
+	for _, msg := range srcTx.Data.Contents.Message {
+		if op, ok := normalizeMessage(&msg); ok {
+			ops = append(ops, op)
+		}
+	}
+	tx.SetOps(ops)
+
+	return tx
+}
+
+// normalizeMessage converts a transfer or staking message into an operation
+func normalizeMessage(msg *Message) (op blockatlas.Op, ok bool) {
+	switch value := msg.Value.(type) {
+	case Particulars:
+		if len(value.Amount) == 0 {
+			return op, false
+		}
+		quantity, err := blockatlas.ParseAmount(value.Amount[0].Quantity, 0)
+		if err != nil {
+			return op, false
+		}
+		return blockatlas.Op{
+			From: value.FromAddr,
+			To:   value.ToAddr,
+			Meta: blockatlas.Transfer{
+				Value: quantity,
+			},
+		}, true
+	case Delegation:
+		var quantity blockatlas.Amount = "0"
+		if value.Amount.Quantity != "" {
+			var err error
+			quantity, err = blockatlas.ParseAmount(value.Amount.Quantity, 0)
+			if err != nil {
+				return op, false
+			}
+		}
+		op = blockatlas.Op{
+			From: value.DelegatorAddr,
+			To:   value.ValidatorAddr,
+		}
+		switch msg.Type {
+		case MsgDelegate:
+			op.Meta = blockatlas.Delegate{
+				Validator: value.ValidatorAddr,
+				Value:     quantity,
+			}
+		case MsgUndelegate:
+			op.Meta = blockatlas.Undelegate{
+				Validator: value.ValidatorAddr,
+				Value:     quantity,
+			}
+		case MsgWithdrawDelegationReward:
+			// Rewards are paid out from the validator to the delegator
+			op.From, op.To = value.ValidatorAddr, value.DelegatorAddr
+			op.Meta = blockatlas.ClaimRewards{
+				Validator: value.ValidatorAddr,
+				Value:     quantity,
+			}
+		}
+		return op, op.Meta != nil
+	default:
+		return op, false
 	}
 }
@@ -2,9 +2,13 @@ package cosmos
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -117,3 +121,96 @@ func TestNormalize(t *testing.T) {
 		t.Error("basic: tx don't equal")
 	}
 }
+
+const delegateSrc = `
+{
+	"height": "152063",
+	"txhash": "5E78C65A8C2BEDF3E9D6E1DDF7F2D4C6B2A2CFC0E1A8B5E1F0C9C3D7A1E4B2F6",
+	"tx": {
+		"type": "auth/StdTx",
+		"value": {
+			"msg": [
+				{
+					"type": "cosmos-sdk/MsgDelegate",
+					"value": {
+						"delegator_address": "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
+						"validator_address": "cosmosvaloper1ey69r37gfxvxg62sh4r0ktpuc46pzjrm873ae8",
+						"amount": {
+							"denom": "uatom",
+							"amount": "1000000"
+						}
+					}
+				}
+			],
+			"fee": {
+				"amount": [
+					{
+						"denom": "uatom",
+						"amount": "5000"
+					}
+				],
+				"gas": "200000"
+			},
+			"memo": ""
+		}
+	},
+	"timestamp": "2019-05-04T18:08:12Z"
+}
+`
+
+var delegateDst = blockatlas.Tx{
+	ID:    "5E78C65A8C2BEDF3E9D6E1DDF7F2D4C6B2A2CFC0E1A8B5E1F0C9C3D7A1E4B2F6",
+	Coin:  coin.ATOM,
+	From:  "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
+	To:    "cosmosvaloper1ey69r37gfxvxg62sh4r0ktpuc46pzjrm873ae8",
+	Fee:   "5000",
+	Date:  1556993292,
+	Block: 152063,
+	Meta: blockatlas.Delegate{
+		Validator: "cosmosvaloper1ey69r37gfxvxg62sh4r0ktpuc46pzjrm873ae8",
+		Value:     "1000000",
+	},
+}
+
+func TestNormalizeDelegate(t *testing.T) {
+	var srcTx Tx
+	err := json.Unmarshal([]byte(delegateSrc), &srcTx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	tx := Normalize(&srcTx)
+	resJSON, err := json.Marshal(&tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstJSON, err := json.Marshal(&delegateDst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(resJSON, dstJSON) {
+		println(string(resJSON))
+		println(string(dstJSON))
+		t.Error("delegate: tx don't equal")
+	}
+}
+
+func TestPlatform_GetTxsByAddress_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("delegator") != "" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	txs, err := p.GetTxsByAddressContext(context.Background(), "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl")
+	if err == nil {
+		t.Errorf("expected the failed delegation lookup to fail the page, got %v", txs)
+	}
+}
@@ -1,9 +1,11 @@
 package cosmos
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -17,36 +19,81 @@ type Client struct {
 	BaseURL    string
 }
 
-// GetAddrTxes - get all ATOM transactions for a given address
-func (c *Client) GetAddrTxes(address string, inOrOut string) (txs []Tx, err error) {
-	var uri string
-
-	if inOrOut == "inputs" {
-		uri = fmt.Sprintf("%s/txs?%s",
-			c.BaseURL,
-			url.Values{
-				"recipient": {address},
-				"page":      {strconv.FormatInt(1, 10)},
-				"limit":     {strconv.FormatInt(blockatlas.TxPerPage, 10)},
-			}.Encode())
-	} else {
-		uri = fmt.Sprintf("%s/txs?%s",
-			c.BaseURL,
-			url.Values{
-				"sender": {address},
-				"page":   {strconv.FormatInt(1, 10)},
-				"limit":  {strconv.FormatInt(blockatlas.TxPerPage, 10)},
-			}.Encode())
+// GetAddrTxes - get all ATOM transactions for a given address.
+// inOrOut selects received ("inputs"), sent ("outputs")
+// or staking ("delegations") transactions.
+func (c *Client) GetAddrTxes(ctx context.Context, address string, inOrOut string) (txs []Tx, err error) {
+	var tag string
+	switch inOrOut {
+	case "inputs":
+		tag = "recipient"
+	case "delegations":
+		tag = "delegator"
+	default:
+		tag = "sender"
 	}
 
-	res, err := c.HTTPClient.Get(uri)
+	uri := fmt.Sprintf("%s/txs?%s",
+		c.BaseURL,
+		url.Values{
+			tag:     {address},
+			"page":  {strconv.FormatInt(1, 10)},
+			"limit": {strconv.FormatInt(blockatlas.TxPerPage, 10)},
+		}.Encode())
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 
 	if err != nil {
 		logrus.WithError(err).Errorf("Cosmos: Failed to get transactions for address %s", address)
 		return txs, err
 	}
+	defer res.Body.Close()
 
 	dec := json.NewDecoder(res.Body)
 	err = dec.Decode(&txs)
 	return txs, err
 }
+
+// GetTx - get a single transaction by its hash
+func (c *Client) GetTx(ctx context.Context, hash string) (*Tx, error) {
+	uri := fmt.Sprintf("%s/txs/%s", c.BaseURL, url.PathEscape(hash))
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Errorf("Cosmos: Failed to get transaction %s", hash)
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, blockatlas.ErrNotFound
+	default:
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	tx := new(Tx)
+	err = json.NewDecoder(res.Body).Decode(tx)
+	return tx, err
+}
+
+// GetBalances - get the balances of all denominations held by an address
+func (c *Client) GetBalances(ctx context.Context, address string) (balances []Amount, err error) {
+	uri := fmt.Sprintf("%s/bank/balances/%s", c.BaseURL, url.PathEscape(address))
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Errorf("Cosmos: Failed to get balances for address %s", address)
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&balances)
+	return balances, err
+}
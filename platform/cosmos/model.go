@@ -1,5 +1,15 @@
 package cosmos
 
+import "encoding/json"
+
+// Types of messages
+const (
+	MsgSend                     = "cosmos-sdk/MsgSend"
+	MsgDelegate                 = "cosmos-sdk/MsgDelegate"
+	MsgUndelegate               = "cosmos-sdk/MsgUndelegate"
+	MsgWithdrawDelegationReward = "cosmos-sdk/MsgWithdrawDelegationReward"
+)
+
 // Tx - Base transaction object. Always returned as part of an array
 type Tx struct {
 	Block string `json:"height"`
@@ -20,18 +30,28 @@ type Contents struct {
 	Memo    string    `json:"memo"`
 }
 
-// Message - an array that holds multiple 'particulars' entries. Possibly used for multiple transfers in one transaction?
+// Message - a single action of a transaction.
+// Value holds Particulars for transfers and Delegation for staking messages.
 type Message struct {
-	Particulars Particulars `json:"value"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
 }
 
-// Particulars - from, to, and amount
+// Particulars - from, to, and amount of a transfer
 type Particulars struct {
 	FromAddr string   `json:"from_address"`
 	ToAddr   string   `json:"to_address"`
 	Amount   []Amount `json:"amount"`
 }
 
+// Delegation - delegator, validator and amount of a staking message.
+// Amount is empty when withdrawing rewards.
+type Delegation struct {
+	DelegatorAddr string `json:"delegator_address"`
+	ValidatorAddr string `json:"validator_address"`
+	Amount        Amount `json:"amount"`
+}
+
 // Fee - also references the "amount" struct
 type Fee struct {
 	FeeAmount []Amount `json:"amount"`
@@ -43,3 +63,26 @@ type Amount struct {
 	Denom    string `json:"denom"`
 	Quantity string `json:"amount"`
 }
+
+func (m *Message) UnmarshalJSON(buf []byte) error {
+	var messageInternal struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	err := json.Unmarshal(buf, &messageInternal)
+	if err != nil {
+		return err
+	}
+	m.Type = messageInternal.Type
+	switch messageInternal.Type {
+	case MsgSend:
+		var particulars Particulars
+		err = json.Unmarshal(messageInternal.Value, &particulars)
+		m.Value = particulars
+	case MsgDelegate, MsgUndelegate, MsgWithdrawDelegationReward:
+		var delegation Delegation
+		err = json.Unmarshal(messageInternal.Value, &delegation)
+		m.Value = delegation
+	}
+	return err
+}
@@ -1,16 +1,18 @@
 package ethereum
 
 import (
-	"fmt"
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"math/big"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
+	"github.com/trustwallet/blockatlas/util"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 )
 
 type Platform struct {
@@ -20,8 +22,8 @@ type Platform struct {
 
 func (p *Platform) Init() error {
 	handle := coin.Coins[p.CoinIndex].Handle
-	p.client.BaseURL = viper.GetString(fmt.Sprintf("%s.api", handle))
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(handle)
+	p.client.HTTPClient = upstream.New(handle)
 	return nil
 }
 
@@ -29,6 +31,15 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[p.CoinIndex]
 }
 
+// ValidateAddress returns the EIP-55 checksum encoding of an address
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	checksummed, err := util.EIP55Checksum(address)
+	if err != nil {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return checksummed, nil
+}
+
 func (p *Platform) RegisterRoutes(router gin.IRouter) {
 	router.GET("/:address", func(c *gin.Context) {
 		p.getTransactions(c)
@@ -39,16 +50,24 @@ func (p *Platform) getTransactions(c *gin.Context) {
 	token := c.Query("token")
 	address := c.Param("address")
 	build := c.Request.Header.Get("client-build")
+	ctx := c.Request.Context()
 	var srcPage *Page
 	var err error
 
+	// The address is passed upstream as given, only its checksum is verified
+	if _, err = p.ValidateAddress(address); err != nil {
+		blockatlas.RenderError(c, p.Coin().Handle, err)
+		return
+	}
+
 	if token != "" {
-		srcPage, err = p.client.GetTxsWithContract(address, token, build)
+		srcPage, err = p.client.GetTxsWithContract(ctx, address, token, build)
 	} else {
-		srcPage, err = p.client.GetTxs(address, build)
+		srcPage, err = p.client.GetTxs(ctx, address, build)
 	}
 
-	if apiError(c, err) {
+	if err != nil {
+		blockatlas.RenderError(c, p.Coin().Handle, err)
 		return
 	}
 
@@ -58,10 +77,101 @@ func (p *Platform) getTransactions(c *gin.Context) {
 	}
 
 	page := blockatlas.TxPage(txs)
+	if token == "" {
+		pending, err := p.getPendingTxs(ctx, address, build)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to get pending transactions")
+		}
+		page.AddPending(pending)
+	}
 	page.Sort()
 	c.JSON(http.StatusOK, &page)
 }
 
+func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+// GetTxsByAddressContext returns the confirmed transactions of an address
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	srcPage, err := p.client.GetTxs(ctx, address, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []blockatlas.Tx
+	for _, srcTx := range srcPage.Docs {
+		txs = AppendTxs(txs, &srcTx, p.CoinIndex)
+	}
+	return txs, nil
+}
+
+func (p *Platform) GetPendingTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetPendingTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetPendingTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	return p.getPendingTxs(ctx, address, "")
+}
+
+func (p *Platform) getPendingTxs(ctx context.Context, address string, build string) (blockatlas.TxPage, error) {
+	srcPage, err := p.client.GetPendingTxs(ctx, address, build)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []blockatlas.Tx
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, srcTx := range srcPage.Docs {
+		// Transactions are timestamped once mined
+		if srcTx.TimeStamp == "" || srcTx.TimeStamp == "0" {
+			srcTx.TimeStamp = now
+		}
+		txs = AppendTxs(txs, &srcTx, p.CoinIndex)
+	}
+	for i := range txs {
+		txs[i].Status = blockatlas.StatusPending
+	}
+	return txs, nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	srcPage, err := p.client.GetTokens(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []blockatlas.Token
+	for _, srcToken := range srcPage.Docs {
+		if token, ok := NormalizeToken(&srcToken); ok {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// NormalizeToken converts a Trust Ray token balance into the generic model
+func NormalizeToken(srcToken *Token) (token blockatlas.Token, ok bool) {
+	if srcToken.Contract == nil {
+		return token, false
+	}
+	balance := srcToken.Balance
+	if balance == "" {
+		balance = "0"
+	}
+	return blockatlas.Token{
+		Name:     srcToken.Contract.Name,
+		Symbol:   srcToken.Contract.Symbol,
+		TokenID:  srcToken.Contract.Address,
+		Decimals: srcToken.Contract.Decimals,
+		Balance:  blockatlas.Amount(balance),
+	}, true
+}
+
 func extractBase(srcTx *Doc, coinIndex uint) (base blockatlas.Tx, ok bool) {
 	var status, errReason string
 	if srcTx.Error == "" {
@@ -76,14 +186,18 @@ func extractBase(srcTx *Doc, coinIndex uint) (base blockatlas.Tx, ok bool) {
 		return base, false
 	}
 
-	fee := calcFee(srcTx.GasPrice, srcTx.GasUsed)
+	// Pending transactions have not used any gas yet
+	fee, err := blockatlas.Amount(srcTx.GasPrice).Mul(blockatlas.Amount(srcTx.GasUsed))
+	if err != nil {
+		fee = "0"
+	}
 
 	base = blockatlas.Tx{
 		ID:       srcTx.ID,
 		Coin:     coinIndex,
 		From:     srcTx.From,
 		To:       srcTx.To,
-		Fee:      blockatlas.Amount(fee),
+		Fee:      fee,
 		Date:     unix,
 		Block:    srcTx.BlockNumber,
 		Status:   status,
@@ -97,6 +211,7 @@ func AppendTxs(in []blockatlas.Tx, srcTx *Doc, coinIndex uint) (out []blockatlas
 	out = in
 	baseTx, ok := extractBase(srcTx, coinIndex)
 	if !ok {
+		metrics.Dropped(coin.Coins[coinIndex].Handle)
 		return
 	}
 
@@ -122,41 +237,38 @@ func AppendTxs(in []blockatlas.Tx, srcTx *Doc, coinIndex uint) (out []blockatlas
 	if len(srcTx.Ops) == 0 {
 		return
 	}
-	op := &srcTx.Ops[0]
-
-	if op.Type == blockatlas.TxTokenTransfer {
-		tokenTx := baseTx
 
-		tokenTx.Meta = blockatlas.TokenTransfer{
-			Name:     op.Contract.Name,
-			Symbol:   op.Contract.Symbol,
-			TokenID:  op.Contract.Address,
-			Decimals: op.Contract.Decimals,
-			Value:    blockatlas.Amount(op.Value),
-			From:     op.From,
-			To:       op.To,
+	var ops []blockatlas.Op
+	for i := range srcTx.Ops {
+		op := &srcTx.Ops[i]
+		if op.Type != blockatlas.TxTokenTransfer || op.Contract == nil {
+			continue
 		}
-		out = append(out, tokenTx)
+		ops = append(ops, blockatlas.Op{
+			From: op.From,
+			To:   op.To,
+			Meta: blockatlas.TokenTransfer{
+				Name:     op.Contract.Name,
+				Symbol:   op.Contract.Symbol,
+				TokenID:  op.Contract.Address,
+				Decimals: op.Contract.Decimals,
+				Value:    blockatlas.Amount(op.Value),
+				From:     op.From,
+				To:       op.To,
+			},
+		})
 	}
-	return
-}
-
-func calcFee(gasPrice string, gasUsed string) string {
-	var gasPriceBig, gasUsedBig, feeBig big.Int
-
-	gasPriceBig.SetString(gasPrice, 10)
-	gasUsedBig.SetString(gasUsed, 10)
-
-	feeBig.Mul(&gasPriceBig, &gasUsedBig)
-
-	return feeBig.String()
-}
-
-func apiError(c *gin.Context, err error) bool {
-	if err != nil {
-		logrus.WithError(err).Errorf("Unhandled error")
-		c.AbortWithStatus(http.StatusInternalServerError)
-		return true
+	// None of the operations is supported
+	if len(ops) == 0 {
+		metrics.Dropped(coin.Coins[coinIndex].Handle)
+		return
 	}
-	return false
+
+	tokenTx := baseTx
+	tokenTx.SetOps(ops)
+	// Sender and recipient stay those of the contract call
+	tokenTx.From = baseTx.From
+	tokenTx.To = baseTx.To
+	out = append(out, tokenTx)
+	return
 }
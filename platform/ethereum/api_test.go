@@ -2,8 +2,12 @@ package ethereum
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/trustwallet/blockatlas/coin"
@@ -230,3 +234,29 @@ func testNormalize(t *testing.T, _test *test) {
 		t.Error(_test.name + ": tx don't equal")
 	}
 }
+
+func TestPlatform_GetTxsByAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions" || r.URL.Query().Get("address") == "" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"total": 2, "docs": [%s, %s]}`, tokenTransferSrc, contractCallSrc)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}, CoinIndex: coin.ETH}
+	txs, err := p.GetTxsByAddressContext(context.Background(), "0xd35f30d194684a391c63a6deced7d3dd5207c265")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(txs))
+	}
+	if _, ok := txs[0].Meta.(blockatlas.TokenTransfer); !ok {
+		t.Errorf("expected token transfer, got %T", txs[0].Meta)
+	}
+	if _, ok := txs[1].Meta.(blockatlas.ContractCall); !ok {
+		t.Errorf("expected contract call, got %T", txs[1].Meta)
+	}
+}
@@ -1,10 +1,12 @@
 package ethereum
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 )
@@ -14,16 +16,16 @@ type Client struct {
 	BaseURL    string
 }
 
-func (c *Client) GetTxs(address string, build string) (*Page, error) {
-	return c.getTxs(fmt.Sprintf("%s/transactions?%s",
+func (c *Client) GetTxs(ctx context.Context, address string, build string) (*Page, error) {
+	return c.getTxs(ctx, fmt.Sprintf("%s/transactions?%s",
 		c.BaseURL,
 		url.Values{
 			"address":  {address},
 		}.Encode()), build)
 }
 
-func (c *Client) GetTxsWithContract(address, contract string, build string) (*Page, error) {
-	return c.getTxs(fmt.Sprintf("%s/transactions?%s",
+func (c *Client) GetTxsWithContract(ctx context.Context, address, contract string, build string) (*Page, error) {
+	return c.getTxs(ctx, fmt.Sprintf("%s/transactions?%s",
 		c.BaseURL,
 		url.Values{
 			"address":  {address},
@@ -31,8 +33,18 @@ func (c *Client) GetTxsWithContract(address, contract string, build string) (*Pa
 		}.Encode()), build)
 }
 
-func (c *Client) getTxs(uri string, build string) (*Page, error) {
-	req, _ := http.NewRequest("GET", uri, nil)
+// GetPendingTxs returns the transactions of an address
+// that have not been mined yet
+func (c *Client) GetPendingTxs(ctx context.Context, address string, build string) (*Page, error) {
+	return c.getTxs(ctx, fmt.Sprintf("%s/transactions/pending?%s",
+		c.BaseURL,
+		url.Values{
+			"address":  {address},
+		}.Encode()), build)
+}
+
+func (c *Client) getTxs(ctx context.Context, uri string, build string) (*Page, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	req.Header.Set("client-build", build)
 
 	res, err := c.HTTPClient.Do(req)
@@ -43,10 +55,39 @@ func (c *Client) getTxs(uri string, build string) (*Page, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("http %s (%s)", res.Status, uri)
+		return nil, blockatlas.ErrStatus(res.StatusCode)
 	}
 
 	txs := new(Page)
 	err = json.NewDecoder(res.Body).Decode(txs)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
 	return txs, nil
 }
+
+func (c *Client) GetTokens(ctx context.Context, address string) (*TokenPage, error) {
+	uri := fmt.Sprintf("%s/tokens?%s",
+		c.BaseURL,
+		url.Values{
+			"address": {address},
+		}.Encode())
+
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Ethereum/Trust Ray: Failed to get tokens")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	tokens := new(TokenPage)
+	err = json.NewDecoder(res.Body).Decode(tokens)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+	return tokens, nil
+}
@@ -33,6 +33,16 @@ type Op struct {
 	Coin     uint      `json:"coin"`
 }
 
+type TokenPage struct {
+	Total uint    `json:"total"`
+	Docs  []Token `json:"docs"`
+}
+
+type Token struct {
+	Balance  string    `json:"balance"`
+	Contract *Contract `json:"contract"`
+}
+
 type Contract struct {
 	Address     string `json:"address"`
 	Symbol      string `json:"symbol"`
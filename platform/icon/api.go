@@ -1,12 +1,12 @@
 package icon
 
 import (
+	"context"
 	"fmt"
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"github.com/trustwallet/blockatlas/util"
-	"net/http"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"time"
 )
 
@@ -15,8 +15,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.RPCURL = viper.GetString("icon.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.RPCURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -25,7 +25,11 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	trxs, err := p.client.GetAddressTransactions(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	trxs, err := p.client.GetAddressTransactions(ctx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +38,7 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	for _, trx := range trxs {
 		nTrx, ok := Normalize(&trx)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		nTrxs = append(nTrxs, nTrx)
@@ -42,6 +47,21 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	return nTrxs, nil
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	info, err := p.client.GetAddressInfo(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if info.Balance == "" {
+		return "0", nil
+	}
+	return blockatlas.ParseAmount(info.Balance, 18)
+}
+
 // Normalize converts an Icon transaction into the generic model
 func Normalize(trx *Tx) (tx blockatlas.Tx, b bool) {
 	date, err := time.Parse("2006-01-02T15:04:05.999Z0700", trx.CreateDate)
@@ -49,21 +69,27 @@ func Normalize(trx *Tx) (tx blockatlas.Tx, b bool) {
 		fmt.Printf("%v\n", err)
 		return tx, false
 	}
-	fee := util.DecimalExp(string(trx.Fee), 18)
-	value := util.DecimalExp(string(trx.Amount), 18)
+	fee, err := blockatlas.ParseAmount(string(trx.Fee), 18)
+	if err != nil {
+		return tx, false
+	}
+	value, err := blockatlas.ParseAmount(string(trx.Amount), 18)
+	if err != nil {
+		return tx, false
+	}
 
 	return blockatlas.Tx{
 		ID:      trx.TxHash,
 		Coin   : coin.ICX,
 		From   : trx.FromAddr,
 		To     : trx.ToAddr,
-		Fee    : blockatlas.Amount(fee),
+		Fee    : fee,
 		Status : blockatlas.StatusCompleted,
 		Date   : date.Unix(),
 		Type   : blockatlas.TxTransfer,
 		Block  : trx.Height,
 		Meta: blockatlas.Transfer{
-			Value : blockatlas.Amount(value),
+			Value : value,
 		},
 	}, true
 }
@@ -1,10 +1,12 @@
 package icon
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -15,7 +17,7 @@ type Client struct {
 	RPCURL     string
 }
 
-func (c *Client) GetAddressTransactions(address string) ([]Tx, error) {
+func (c *Client) GetAddressTransactions(ctx context.Context, address string) ([]Tx, error) {
 	uri := fmt.Sprintf("%s/address/txList?%s",
 		c.RPCURL,
 		url.Values{
@@ -23,7 +25,7 @@ func (c *Client) GetAddressTransactions(address string) ([]Tx, error) {
 			"count": {strconv.FormatInt(blockatlas.TxPerPage, 10)},
 		}.Encode())
 
-	httpRes, err := c.HTTPClient.Get(uri)
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
 
 	if err != nil {
 		logrus.WithError(err).Errorf("ICON: Failed to get transactions for address %s", address)
@@ -40,3 +42,30 @@ func (c *Client) GetAddressTransactions(address string) ([]Tx, error) {
 
 	return res.Data, nil
 }
+
+func (c *Client) GetAddressInfo(ctx context.Context, address string) (*AddressInfo, error) {
+	uri := fmt.Sprintf("%s/address/info?%s",
+		c.RPCURL,
+		url.Values{
+			"address": {address},
+		}.Encode())
+
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Errorf("ICON: Failed to get info for address %s", address)
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	var res AddressInfoResponse
+	derr := json.NewDecoder(httpRes.Body).Decode(&res)
+
+	if res.Description != "success" {
+		if derr == nil {
+			derr = fmt.Errorf("ICON: %s", res.Description)
+		}
+		return nil, derr
+	}
+
+	return &res.Data, nil
+}
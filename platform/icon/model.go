@@ -9,6 +9,20 @@ type Response struct {
 	Description string `json:"description"`
 }
 
+// AddressInfoResponse describes the address info response
+type AddressInfoResponse struct {
+	Data        AddressInfo `json:"data"`
+	Result      string      `json:"result"`
+	Description string      `json:"description"`
+}
+
+// AddressInfo describes an ICON address
+type AddressInfo struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+	Nonce   string `json:"nonce"`
+}
+
 // Tx describes the ripple transaction
 type Tx struct {
 	TxHash     string `json:"txHash"`
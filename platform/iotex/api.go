@@ -1,14 +1,14 @@
 package iotex
 
 import (
+	"context"
 	"github.com/trustwallet/blockatlas"
-	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/spf13/viper"
-
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 )
 
 type Platform struct {
@@ -16,8 +16,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("iotex.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -26,28 +26,78 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	var start int64
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
 
-	totalTrx, err := p.client.GetAddressTotalTransactions(address)
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	page, _, err := p.GetTxPageByAddressContext(ctx, address, "")
+	return page, err
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
 
-	if totalTrx >= blockatlas.TxPerPage {
-		start = totalTrx - blockatlas.TxPerPage
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The cursor is the (exclusive) index of the newest action to return.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	var end int64
+	if cursor == "" {
+		totalTrx, err := p.client.GetAddressTotalTransactions(ctx, address)
+		if err != nil {
+			return nil, "", err
+		}
+		end = totalTrx
+	} else {
+		var err error
+		end, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || end < 0 {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
 	}
 
-	trxs, err := p.client.GetTxsOfAddress(address, start)
+	if end == 0 {
+		return nil, "", nil
+	}
+
+	var start int64
+	if end >= blockatlas.TxPerPage {
+		start = end - blockatlas.TxPerPage
+	}
+
+	trxs, err := p.client.GetTxsOfAddress(ctx, address, start, end-start)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var txs []blockatlas.Tx
 	for _, srcTx := range trxs.ActionInfo {
 		tx := Normalize(srcTx)
-		if tx != nil {
-			txs = append(txs, *tx)
+		if tx == nil {
+			metrics.Dropped(p.Coin().Handle)
+			continue
 		}
+		txs = append(txs, *tx)
 	}
 
-	return txs, nil
+	var next string
+	if start > 0 {
+		next = strconv.FormatInt(start, 10)
+	}
+
+	return txs, next, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return account.Balance, nil
 }
 
 // Normalize converts an Iotex transaction into the generic model
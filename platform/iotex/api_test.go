@@ -1,8 +1,12 @@
 package iotex
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,3 +131,52 @@ func TestNormalize(t *testing.T) {
 		a.Equal(expected[i], tx)
 	}
 }
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	a := assert.New(t)
+
+	// The address has 30 actions, pages are requested by start and count
+	const address = "io1mwekae7qqwlr23220k5n9z3fmjxz72tuchra3m"
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/" + address:
+			fmt.Fprintf(w, `{"accountMeta": {"address": "%s", "numActions": "30"}}`, address)
+		case "/actions/addr/" + address:
+			requests = append(requests, r.URL.RawQuery)
+			fmt.Fprint(w, transfer)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tests := []struct {
+		cursor string
+		query  string
+		next   string
+	}{
+		{"", "count=25&start=5", "5"},
+		{"5", "count=5&start=0", ""},
+		{"0", "", ""},
+	}
+	for _, test := range tests {
+		requests = nil
+		page, next, err := p.GetTxPageByAddressContext(context.Background(), address, test.cursor)
+		a.NoError(err, test.cursor)
+		a.Equal(test.next, next, test.cursor)
+		if test.query == "" {
+			a.Empty(requests, test.cursor)
+			a.Empty(page, test.cursor)
+		} else {
+			a.Equal([]string{test.query}, requests, test.cursor)
+			a.Len(page, 1, test.cursor)
+		}
+	}
+
+	for _, cursor := range []string{"last", "-5"} {
+		_, _, err := p.GetTxPageByAddressContext(context.Background(), address, cursor)
+		a.Equal(blockatlas.ErrInvalidCursor, err, cursor)
+	}
+}
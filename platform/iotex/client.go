@@ -1,9 +1,11 @@
 package iotex
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -16,17 +18,17 @@ type Client struct {
 	BaseURL    string
 }
 
-func (c *Client) GetTxsOfAddress(address string, start int64) (*Response, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, start int64, count int64) (*Response, error) {
 	uri := fmt.Sprintf("%s/actions/addr/%s?%s",
 		c.BaseURL,
 		address,
 		url.Values{
 			"start": {strconv.FormatInt(start, 10)},
-			"count": {strconv.FormatInt(blockatlas.TxPerPage, 10)},
+			"count": {strconv.FormatInt(count, 10)},
 		}.Encode(),
 	)
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if res != nil {
 		defer res.Body.Close()
 	}
@@ -38,7 +40,7 @@ func (c *Client) GetTxsOfAddress(address string, start int64) (*Response, error)
 
 	var act Response
 	if res.StatusCode != http.StatusOK {
-		err = fmt.Errorf("HTTP status: %s", res.Status)
+		err = blockatlas.ErrStatus(res.StatusCode)
 		return &act, err
 	}
 
@@ -49,26 +51,38 @@ func (c *Client) GetTxsOfAddress(address string, start int64) (*Response, error)
 	return &act, nil
 }
 
-func (c *Client) GetAddressTotalTransactions(address string) (int64, error) {
+func (c *Client) GetAccount(ctx context.Context, address string) (*AccountMeta, error) {
 	uri := fmt.Sprintf("%s/accounts/%s", c.BaseURL, address)
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if res != nil {
 		defer res.Body.Close()
 	}
 
 	if err != nil {
-		logrus.WithError(err).Errorf("IOTEX: Failed to get transactions for address %s", address)
-		return 0, err
+		logrus.WithError(err).Errorf("IOTEX: Failed to get account %s", address)
+		return nil, err
 	}
 
 	var account AccountInfo
-	
+
 	if err := json.NewDecoder(res.Body).Decode(&account); err != nil {
+		return nil, err
+	}
+	if account.AccountMeta == nil {
+		return nil, blockatlas.ErrNotFound
+	}
+
+	return account.AccountMeta, nil
+}
+
+func (c *Client) GetAddressTotalTransactions(ctx context.Context, address string) (int64, error) {
+	account, err := c.GetAccount(ctx, address)
+	if err != nil {
 		return 0, err
 	}
 
-	numActions, err := strconv.ParseInt(account.AccountMeta.NumActions, 10, 64)
+	numActions, err := strconv.ParseInt(account.NumActions, 10, 64)
 	if err != nil {
 		return 0, err
 	}
@@ -11,7 +11,9 @@ type AccountInfo struct {
 }
 
 type AccountMeta struct {
-	NumActions   string   `json:"numActions"`
+	Address    string            `json:"address"`
+	Balance    blockatlas.Amount `json:"balance"`
+	NumActions string            `json:"numActions"`
 }
 
 type ActionInfo struct {
@@ -0,0 +1,49 @@
+package nimiq
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// addressAlphabet is the Base32 alphabet of Nimiq addresses
+const addressAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVXY"
+
+// CheckAddress verifies the IBAN checksum of a Nimiq address
+// and returns its canonical user-friendly form
+// (upper-case, groups of four characters separated by spaces).
+//  - "nq699a4amb83hxdq4j46bh5r4jffqma9c3gn" => "NQ69 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3GN"
+func CheckAddress(address string) (string, error) {
+	compact := normalizeAddress(address)
+	if len(compact) != 36 || !strings.HasPrefix(compact, "NQ") {
+		return "", fmt.Errorf("not a Nimiq address: %s", address)
+	}
+	for _, c := range compact[4:] {
+		if !strings.ContainsRune(addressAlphabet, c) {
+			return "", fmt.Errorf("not a Nimiq address: %s", address)
+		}
+	}
+
+	// ISO 13616: Move the country code and check digits to the end,
+	// replace letters by numbers (A = 10, ..., Z = 35), remainder of 97 must be 1
+	var digits strings.Builder
+	for _, c := range compact[4:] + compact[:4] {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(fmt.Sprint(c - 'A' + 10))
+		} else if c >= '0' && c <= '9' {
+			digits.WriteRune(c)
+		} else {
+			return "", fmt.Errorf("not a Nimiq address: %s", address)
+		}
+	}
+	num, _ := new(big.Int).SetString(digits.String(), 10)
+	if num.Mod(num, big.NewInt(97)).Int64() != 1 {
+		return "", fmt.Errorf("invalid checksum: %s", address)
+	}
+
+	groups := make([]string, 0, 9)
+	for i := 0; i < len(compact); i += 4 {
+		groups = append(groups, compact[i:i+4])
+	}
+	return strings.Join(groups, " "), nil
+}
@@ -1,9 +1,13 @@
 package nimiq
 
 import (
-	"github.com/spf13/viper"
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/upstream"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type Platform struct {
@@ -11,8 +15,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("nimiq.api")
-	p.client.Init()
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -20,12 +24,29 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[coin.NIM]
 }
 
+// ValidateAddress returns the user friendly form of an address
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	canonical, err := CheckAddress(address)
+	if err != nil {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return canonical, nil
+}
+
 func (p *Platform) CurrentBlockNumber() (int64, error) {
-	return p.client.CurrentBlockNumber()
+	return p.CurrentBlockNumberContext(context.Background())
+}
+
+func (p *Platform) CurrentBlockNumberContext(ctx context.Context) (int64, error) {
+	return p.client.CurrentBlockNumber(ctx)
 }
 
 func (p *Platform) GetBlockByNumber(num int64) (*blockatlas.Block, error) {
-	if srcBlock, err := p.client.GetBlockByNumber(num); err == nil {
+	return p.GetBlockByNumberContext(context.Background(), num)
+}
+
+func (p *Platform) GetBlockByNumberContext(ctx context.Context, num int64) (*blockatlas.Block, error) {
+	if srcBlock, err := p.client.GetBlockByNumber(ctx, num); err == nil {
 		block := NormalizeBlock(srcBlock)
 		return &block, nil
 	} else {
@@ -34,15 +55,113 @@ func (p *Platform) GetBlockByNumber(num int64) (*blockatlas.Block, error) {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	if srcTxs, err := p.client.GetTxsOfAddress(address, blockatlas.TxPerPage); err == nil {
-		return NormalizeTxs(srcTxs), err
-	} else {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	page, _, err := p.GetTxPageByAddressContext(ctx, address, "")
+	return page, err
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
+
+// maxSkip bounds the cursor, the node returns all
+// skipped transactions along with each page
+const maxSkip = 1000
+
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The node only returns the newest n transactions of an address,
+// so the cursor is the number of newer transactions to skip.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	var skip int
+	if cursor != "" {
+		var err error
+		skip, err = strconv.Atoi(cursor)
+		if err != nil || skip < 0 || skip > maxSkip {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
+	}
+
+	count := skip + blockatlas.TxPerPage
+	srcTxs, err := p.client.GetTxsOfAddress(ctx, address, count)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(srcTxs) <= skip {
+		return nil, "", nil
+	}
+
+	// Newest transactions first
+	sort.SliceStable(srcTxs, func(i, j int) bool {
+		return srcTxs[i].BlockNumber > srcTxs[j].BlockNumber
+	})
+
+	var next string
+	if len(srcTxs) >= count && count <= maxSkip {
+		next = strconv.Itoa(count)
+	}
+
+	return NormalizeTxs(srcTxs[skip:]), next, nil
+}
+
+func (p *Platform) GetPendingTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetPendingTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetPendingTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	srcTxs, err := p.client.GetMempoolTxs(ctx)
+	if err != nil {
 		return nil, err
 	}
+
+	address = normalizeAddress(address)
+	var txs []blockatlas.Tx
+	for _, srcTx := range srcTxs {
+		if normalizeAddress(srcTx.FromAddress) != address &&
+			normalizeAddress(srcTx.ToAddress) != address {
+			continue
+		}
+		txs = append(txs, NormalizeTx(&srcTx))
+	}
+	return txs, nil
 }
 
-// NormalizeTx converts a Nimiq transaction into the generic model
+// normalizeAddress strips the spaces and case
+// of user friendly addresses for comparison
+func normalizeAddress(address string) string {
+	return strings.ToUpper(strings.Replace(address, " ", "", -1))
+}
+
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	srcTx, err := p.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tx := NormalizeTx(srcTx)
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	return p.client.GetBalance(ctx, address)
+}
+
+// NormalizeTx converts a Nimiq transaction into the generic model.
+// Transactions without a block are pending.
 func NormalizeTx(srcTx *Tx) blockatlas.Tx {
+	var status string
+	if srcTx.BlockHash == "" {
+		status = blockatlas.StatusPending
+	}
 	return blockatlas.Tx{
 		ID:    srcTx.Hash,
 		Coin:  coin.Coins[coin.NIM].ID,
@@ -51,6 +170,7 @@ func NormalizeTx(srcTx *Tx) blockatlas.Tx {
 		To:    srcTx.ToAddress,
 		Fee:   srcTx.Fee,
 		Block: srcTx.BlockNumber,
+		Status: status,
 		Meta:  blockatlas.Transfer{
 			Value: srcTx.Value,
 		},
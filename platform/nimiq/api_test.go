@@ -2,9 +2,14 @@ package nimiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -65,3 +70,85 @@ func TestNormalizeTx(t *testing.T) {
 		t.Error("basic: tx don't equal")
 	}
 }
+
+func TestCheckAddress(t *testing.T) {
+	expected := "NQ69 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3GN"
+	for _, input := range []string{
+		expected,
+		"NQ699A4AMB83HXDQ4J46BH5R4JFFQMA9C3GN",
+		"nq69 9a4a mb83 hxdq 4j46 bh5r 4jff qma9 c3gn",
+	} {
+		actual, err := CheckAddress(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err)
+		} else if actual != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, actual)
+		}
+	}
+
+	for _, input := range []string{
+		"NQ68 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3GN",
+		"NQ69 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3G",
+		"NQ69 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3GZ",
+		"DE69 9A4A MB83 HXDQ 4J46 BH5R 4JFF QMA9 C3GN",
+	} {
+		if _, err := CheckAddress(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	// The node returns at most the requested number of the 30 transactions
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req struct {
+			ID     int           `json:"id"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Params) != 2 {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		count := int(req.Params[1].(float64))
+		if count > 30 {
+			count = 30
+		}
+		txs := strings.TrimSuffix(strings.Repeat(basicSrc+",", count), ",")
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": [%s]}`, req.ID, txs)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tests := []struct {
+		cursor string
+		txs    int
+		next   string
+	}{
+		{"", 25, "25"},
+		{"25", 5, ""},
+		{"30", 0, ""},
+	}
+	for _, test := range tests {
+		page, next, err := p.GetTxPageByAddressContext(context.Background(), basicDst.From, test.cursor)
+		if err != nil {
+			t.Fatalf("cursor %q: %v", test.cursor, err)
+		}
+		if len(page) != test.txs || next != test.next {
+			t.Errorf("cursor %q: expected %d transactions and next %q, got %d and %q",
+				test.cursor, test.txs, test.next, len(page), next)
+		}
+	}
+
+	requests = 0
+	for _, cursor := range []string{"first", "-25", "1025"} {
+		_, _, err := p.GetTxPageByAddressContext(context.Background(), basicDst.From, cursor)
+		if err != blockatlas.ErrInvalidCursor {
+			t.Errorf("cursor %q: expected invalid cursor, got %v", cursor, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests for invalid cursors, got %d", requests)
+	}
+}
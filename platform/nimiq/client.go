@@ -1,22 +1,29 @@
 package nimiq
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/ybbus/jsonrpc"
+	"net/http"
 )
 
 type Client struct {
-	BaseURL   string
-	rpcClient jsonrpc.RPCClient
+	BaseURL    string
+	HTTPClient *http.Client
 }
 
-func (c *Client) Init() {
-	c.rpcClient = jsonrpc.NewClient(c.BaseURL)
+// rpc returns a JSON-RPC client whose requests are bound to the context.
+// All calls are read-only, they are retried on other endpoints.
+func (c *Client) rpc(ctx context.Context) jsonrpc.RPCClient {
+	return jsonrpc.NewClientWithOpts(c.BaseURL, &jsonrpc.RPCClientOpts{
+		HTTPClient: upstream.WithContext(upstream.Idempotent(ctx), c.HTTPClient),
+	})
 }
 
-func (c *Client) GetTxsOfAddress(address string, count int) (txs []Tx, err error) {
-	err = c.rpcClient.CallFor(&txs, "getTransactionsByAddress", address, count)
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, count int) (txs []Tx, err error) {
+	err = c.rpc(ctx).CallFor(&txs, "getTransactionsByAddress", address, count)
 	if jErr, ok := err.(*jsonrpc.RPCError); ok {
 		if jErr.Code == 1 {
 			return nil, blockatlas.ErrInvalidAddr
@@ -30,13 +37,40 @@ func (c *Client) GetTxsOfAddress(address string, count int) (txs []Tx, err error
 	return
 }
 
-func (c *Client) CurrentBlockNumber() (num int64, err error) {
-	err = c.rpcClient.CallFor(&num, "blockNumber")
+// GetMempoolTxs returns all transactions that are not included in a block yet
+func (c *Client) GetMempoolTxs(ctx context.Context) (txs []Tx, err error) {
+	err = c.rpc(ctx).CallFor(&txs, "mempoolContent", true)
+	if err != nil {
+		logrus.WithError(err).Error("Nimiq: Failed to get mempool")
+		return nil, blockatlas.ErrSourceConn
+	}
+	return
+}
+
+func (c *Client) GetTx(ctx context.Context, hash string) (tx *Tx, err error) {
+	err = c.rpc(ctx).CallFor(&tx, "getTransactionByHash", hash)
+	if err != nil {
+		logrus.WithError(err).Error("Nimiq: Failed to get transaction")
+		return nil, blockatlas.ErrSourceConn
+	}
+	if tx == nil {
+		return nil, blockatlas.ErrNotFound
+	}
+	return
+}
+
+func (c *Client) GetBalance(ctx context.Context, address string) (balance blockatlas.Amount, err error) {
+	err = c.rpc(ctx).CallFor(&balance, "getBalance", address)
+	return
+}
+
+func (c *Client) CurrentBlockNumber(ctx context.Context) (num int64, err error) {
+	err = c.rpc(ctx).CallFor(&num, "blockNumber")
 	return
 }
 
-func (c *Client) GetBlockByNumber(num int64) (block *Block, err error) {
+func (c *Client) GetBlockByNumber(ctx context.Context, num int64) (block *Block, err error) {
 	block = new(Block)
-	err = c.rpcClient.CallFor(block, "getBlockByNumber", num, true)
+	err = c.rpc(ctx).CallFor(block, "getBlockByNumber", num, true)
 	return
 }
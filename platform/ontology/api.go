@@ -1,13 +1,12 @@
 package ontology
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"github.com/trustwallet/blockatlas/util"
-	"net/http"
-	"strings"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 )
 
 type Platform struct {
@@ -21,8 +20,8 @@ const (
 )
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("ontology.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -31,11 +30,19 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	return p.GetTokenTxsByAddress(address, ONTAssetName)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	return p.GetTokenTxsByAddressContext(ctx, address, ONTAssetName)
 }
 
 func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
-	txPage, err := p.client.GetTxsOfAddress(address, token)
+	return p.GetTokenTxsByAddressContext(context.Background(), address, token)
+}
+
+func (p *Platform) GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (blockatlas.TxPage, error) {
+	txPage, err := p.client.GetTxsOfAddress(ctx, address, token)
 
 	if err != nil {
 		logrus.WithError(err).WithField("platform", "ontology").
@@ -46,6 +53,7 @@ func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatla
 	for _, srcTx := range txPage.Result.TxnList {
 		tx, ok := Normalize(&srcTx, token)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		txs = append(txs, tx)
@@ -54,12 +62,71 @@ func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatla
 	return txs, nil
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	// The address info of the explorer contains the balances of all assets
+	txPage, err := p.client.GetTxsOfAddress(ctx, address, ONTAssetName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, balance := range txPage.Result.AssetBalance {
+		if balance.AssetName != ONTAssetName {
+			continue
+		}
+		return blockatlas.ParseAmount(balance.Balance, 0)
+	}
+
+	return "0", nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	txPage, err := p.client.GetTxsOfAddress(ctx, address, ONGAssetName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []blockatlas.Token
+	for _, balance := range txPage.Result.AssetBalance {
+		if balance.AssetName != ONGAssetName {
+			continue
+		}
+		value, err := blockatlas.ParseAmount(balance.Balance, 9)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, blockatlas.Token{
+			Name:     "Ontology Gas",
+			Symbol:   "ONG",
+			TokenID:  "ong",
+			Decimals: 9,
+			Balance:  value,
+		})
+	}
+	return tokens, nil
+}
+
+// Normalize converts an Ontology transaction into the generic model.
+// Every transfer of the asset becomes an operation. The ONG fee paid
+// to the governance contract is only kept if there is no other transfer.
 func Normalize(srcTx *Tx, assetName string) (tx blockatlas.Tx, ok bool) {
 	if len(srcTx.TransferList) < 1 {
 		return tx, false
 	}
-	transfer := srcTx.TransferList[0]
-	fee := util.DecimalExp(srcTx.Fee, 9)
+	if assetName != ONTAssetName && assetName != ONGAssetName {
+		return tx, false
+	}
+	fee, err := blockatlas.ParseAmount(srcTx.Fee, 9)
+	if err != nil {
+		return tx, false
+	}
 	var status string
 	if srcTx.ConfirmFlag == 1 {
 		status = blockatlas.StatusCompleted
@@ -70,56 +137,113 @@ func Normalize(srcTx *Tx, assetName string) (tx blockatlas.Tx, ok bool) {
 	tx = blockatlas.Tx{
 		ID: srcTx.TxnHash,
 		Coin: coin.ONT,
-		Fee: blockatlas.Amount(fee),
+		Fee: fee,
 		Date:  srcTx.TxnTime,
 		Block: srcTx.Height,
 		Status: status,
 	}
 
-	switch assetName {
-	case ONTAssetName:
-		normalizeONT(&tx, &transfer)
-	case ONGAssetName:
-		normalizeONG(&tx, &transfer)
-	default: // unsupported asset
-		return tx, false
+	var ops, fees []blockatlas.Op
+	for i := range srcTx.TransferList {
+		transfer := &srcTx.TransferList[i]
+		if transfer.AssetName != assetName {
+			continue
+		}
+		var op blockatlas.Op
+		if assetName == ONTAssetName {
+			op, ok = normalizeONT(transfer)
+		} else {
+			op, ok = normalizeONG(transfer)
+		}
+		if !ok {
+			return tx, false
+		}
+		if assetName == ONGAssetName && transfer.ToAddress == GovernanceContract {
+			fees = append(fees, op)
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		// Transactions of other assets only pay the fee in ONG
+		if len(fees) == 0 {
+			return tx, false
+		}
+		ops = fees[:1]
 	}
+	tx.SetOps(ops)
 
 	return tx, true
 }
 
-func normalizeONT(tx *blockatlas.Tx, transfer *Transfer) {
-	i := strings.IndexRune(transfer.Amount, '.')
-	value := transfer.Amount[:i]
+func normalizeONT(transfer *Transfer) (op blockatlas.Op, ok bool) {
+	// ONT is indivisible
+	value, err := blockatlas.ParseAmount(transfer.Amount, 0)
+	if err != nil {
+		return op, false
+	}
+
+	op = blockatlas.Op{
+		From: transfer.FromAddress,
+		To:   transfer.ToAddress,
+	}
 
-	tx.From = transfer.FromAddress
-	tx.To = transfer.ToAddress
-	tx.Type = blockatlas.TxTransfer
-	tx.Meta = blockatlas.Transfer{
-		Value: blockatlas.Amount(value),
+	// ONT sent to or received from the governance contract is staked or unstaked
+	switch GovernanceContract {
+	case transfer.ToAddress:
+		op.Meta = blockatlas.Delegate{
+			Validator: GovernanceContract,
+			Value:     value,
+		}
+	case transfer.FromAddress:
+		op.Meta = blockatlas.Undelegate{
+			Validator: GovernanceContract,
+			Value:     value,
+		}
+	default:
+		op.Meta = blockatlas.Transfer{
+			Value: value,
+		}
 	}
+	return op, true
 }
 
-func normalizeONG(tx *blockatlas.Tx, transfer *Transfer) {
-	var value string
-	if transfer.ToAddress == GovernanceContract {
-		value = "0"
-	} else {
-		value = util.DecimalExp(transfer.Amount, 9)
+func normalizeONG(transfer *Transfer) (op blockatlas.Op, ok bool) {
+	var value blockatlas.Amount = "0"
+	if transfer.ToAddress != GovernanceContract {
+		var err error
+		value, err = blockatlas.ParseAmount(transfer.Amount, 9)
+		if err != nil {
+			return op, false
+		}
 	}
 
 	from := transfer.FromAddress
 	to := transfer.ToAddress
-	tx.From = from
-	tx.To = to
-	tx.Type = blockatlas.TxNativeTokenTransfer
-	tx.Meta = blockatlas.NativeTokenTransfer{
-		Name: "Ontology Gas",
-		Symbol: "ONG",
-		TokenID: "ong",
-		Decimals: 9,
-		Value: blockatlas.Amount(value),
-		From: from,
-		To: to,
+
+	// ONG received from the governance contract is a staking reward
+	if from == GovernanceContract {
+		return blockatlas.Op{
+			From: from,
+			To:   to,
+			Meta: blockatlas.ClaimRewards{
+				Validator: GovernanceContract,
+				Value:     value,
+			},
+		}, true
 	}
+
+	return blockatlas.Op{
+		From: from,
+		To:   to,
+		Meta: blockatlas.NativeTokenTransfer{
+			Name: "Ontology Gas",
+			Symbol: "ONG",
+			TokenID: "ong",
+			Decimals: 9,
+			Value: value,
+			From: from,
+			To: to,
+		},
+	}, true
 }
@@ -131,6 +131,53 @@ var dstOngTransfer = blockatlas.Tx{
 	},
 }
 
+var srcOntTransferWithFee = `
+{
+	"TxnType": 209,
+	"ConfirmFlag": 1,
+	"Fee": "0.010000000",
+	"BlockIndex": 2,
+	"TransferList": [
+		{
+			"FromAddress": "AUyL4TZ1zFEcSKDJrjFnD7vsq5iFZMZqT7",
+			"Amount": "2.000000000",
+			"ToAddress": "AQ9kzzHNLCcyrPwJuVMrSPgGzqmuQNVwMF",
+			"AssetName": "ont"
+		},
+		{
+			"FromAddress": "AUyL4TZ1zFEcSKDJrjFnD7vsq5iFZMZqT7",
+			"Amount": "0.010000000",
+			"ToAddress": "AFmseVrdL9f9oyCzZefL9tG6UbviEH9ugK",
+			"AssetName": "ong"
+		}
+	],
+	"TxnTime": 1556952450,
+	"TxnHash": "4804e1be63ebe1715d6b4a039cc9d84b86cde74c8a8c8411578e6dcadc1e5405",
+	"Height": 3411115
+}
+`
+
+var dstOntTransferFee = blockatlas.Tx{
+	ID:     "4804e1be63ebe1715d6b4a039cc9d84b86cde74c8a8c8411578e6dcadc1e5405",
+	Coin:   coin.ONT,
+	From:   "AUyL4TZ1zFEcSKDJrjFnD7vsq5iFZMZqT7",
+	To:     "AFmseVrdL9f9oyCzZefL9tG6UbviEH9ugK",
+	Fee:    "10000000",
+	Date:   1556952450,
+	Type:   blockatlas.TxNativeTokenTransfer,
+	Status: "completed",
+	Block:  3411115,
+	Meta: blockatlas.NativeTokenTransfer{
+		Name:     "Ontology Gas",
+		Symbol:   "ONG",
+		TokenID:  "ong",
+		Decimals: 9,
+		Value:    "0",
+		From:     "AUyL4TZ1zFEcSKDJrjFnD7vsq5iFZMZqT7",
+		To:       "AFmseVrdL9f9oyCzZefL9tG6UbviEH9ugK",
+	},
+}
+
 func TestNormalize(t *testing.T) {
 	var tests = []struct {
 		Transaction string
@@ -140,6 +187,8 @@ func TestNormalize(t *testing.T) {
 		{srcOntTransfer, ONTAssetName, dstOntTransfer},
 		{srcOngTransfer1, ONGAssetName, dstOngTransfer1},
 		{srcOngTransfer2, ONGAssetName, dstOngTransfer},
+		{srcOntTransferWithFee, ONTAssetName, dstOntTransfer},
+		{srcOntTransferWithFee, ONGAssetName, dstOntTransferFee},
 	}
 
 	for _, test := range tests {
@@ -1,9 +1,12 @@
 package ontology
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 )
 
@@ -16,7 +19,7 @@ type Client struct {
 // Explorer API max returned transactions per page
 const TxPerPage = 20
 
-func (c *Client) GetTxsOfAddress(address, assetName string) (*TxPage, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address, assetName string) (*TxPage, error) {
 	uri := fmt.Sprintf("%s/address/%s/%s/%d/1",
 		c.BaseURL,
 		address,
@@ -24,7 +27,7 @@ func (c *Client) GetTxsOfAddress(address, assetName string) (*TxPage, error) {
 		TxPerPage,
 	)
 
-	res, err := c.HTTPClient.Get(uri)
+	res, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Errorf("Ontology: Failed to get transactions for address %s for asset %s", address, assetName)
 		return nil, err
@@ -34,7 +37,7 @@ func (c *Client) GetTxsOfAddress(address, assetName string) (*TxPage, error) {
 	txPage := new(TxPage)
 	err = json.NewDecoder(res.Body).Decode(txPage)
 	if err != nil {
-		return nil, err
+		return nil, blockatlas.ErrDecode(err)
 	}
 
 	return txPage, nil
@@ -5,13 +5,20 @@ type TxPage struct {
 }
 
 type Result struct {
-	TxnList []Tx `json:"TxnList"`
+	AssetBalance []AssetBalance `json:"AssetBalance"`
+	TxnList      []Tx           `json:"TxnList"`
+}
+
+type AssetBalance struct {
+	AssetName string `json:"AssetName"`
+	Balance   string `json:"Balance"`
 }
 
 type Transfer struct {
 	Amount      string `json:"Amount"`
 	FromAddress string `json:"FromAddress"`
 	ToAddress   string `json:"ToAddress"`
+	AssetName   string `json:"AssetName"`
 }
 
 type Tx struct {
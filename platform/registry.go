@@ -1,15 +1,51 @@
 package platform
 
 import (
-	"fmt"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/platform/aion"
+	"github.com/trustwallet/blockatlas/platform/binance"
+	"github.com/trustwallet/blockatlas/platform/cosmos"
+	"github.com/trustwallet/blockatlas/platform/ethereum"
+	"github.com/trustwallet/blockatlas/platform/icon"
+	"github.com/trustwallet/blockatlas/platform/iotex"
 	"github.com/trustwallet/blockatlas/platform/nimiq"
+	"github.com/trustwallet/blockatlas/platform/ontology"
+	"github.com/trustwallet/blockatlas/platform/ripple"
+	"github.com/trustwallet/blockatlas/platform/semux"
+	"github.com/trustwallet/blockatlas/platform/stellar"
+	"github.com/trustwallet/blockatlas/platform/tezos"
+	"github.com/trustwallet/blockatlas/platform/theta"
+	"github.com/trustwallet/blockatlas/platform/tron"
+	"github.com/trustwallet/blockatlas/platform/vechain"
+	"github.com/trustwallet/blockatlas/platform/waves"
+	"github.com/trustwallet/blockatlas/platform/zilliqa"
+	"github.com/trustwallet/blockatlas/upstream"
+	"sort"
 )
 
-var platformList = []blockatlas.Platform{
-	&nimiq.Platform{},
+// platformFactories create the platform of a coin by the name of
+// its implementation (the platform or handle of the coin in coins.yml).
+// Platforms serving multiple coins receive the coin ID.
+var platformFactories = map[string]func(coinID uint) blockatlas.Platform{
+	"aion":     func(uint) blockatlas.Platform { return &aion.Platform{} },
+	"binance":  func(uint) blockatlas.Platform { return &binance.Platform{} },
+	"cosmos":   func(uint) blockatlas.Platform { return &cosmos.Platform{} },
+	"ethereum": func(id uint) blockatlas.Platform { return &ethereum.Platform{CoinIndex: id} },
+	"icon":     func(uint) blockatlas.Platform { return &icon.Platform{} },
+	"iotex":    func(uint) blockatlas.Platform { return &iotex.Platform{} },
+	"nimiq":    func(uint) blockatlas.Platform { return &nimiq.Platform{} },
+	"ontology": func(uint) blockatlas.Platform { return &ontology.Platform{} },
+	"ripple":   func(uint) blockatlas.Platform { return &ripple.Platform{} },
+	"semux":    func(uint) blockatlas.Platform { return &semux.Platform{} },
+	"stellar":  func(id uint) blockatlas.Platform { return &stellar.Platform{CoinIndex: id} },
+	"tezos":    func(uint) blockatlas.Platform { return &tezos.Platform{} },
+	"theta":    func(uint) blockatlas.Platform { return &theta.Platform{} },
+	"tron":     func(uint) blockatlas.Platform { return &tron.Platform{} },
+	"vechain":  func(uint) blockatlas.Platform { return &vechain.Platform{} },
+	"waves":    func(uint) blockatlas.Platform { return &waves.Platform{} },
+	"zilliqa":  func(uint) blockatlas.Platform { return &zilliqa.Platform{} },
 }
 
 // Platforms contains all registered platforms by handle
@@ -18,11 +54,9 @@ var Platforms map[string]blockatlas.Platform
 func Init() {
 	Platforms = make(map[string]blockatlas.Platform)
 
-	for _, platform := range platformList {
+	for _, platform := range platformList() {
 		handle := platform.Coin().Handle
-		apiKey := fmt.Sprintf("%s.api", handle)
-
-		if viper.GetString(apiKey) == "" {
+		if len(upstream.Endpoints(handle)) == 0 {
 			continue
 		}
 
@@ -43,3 +77,39 @@ func Init() {
 		Platforms[handle] = platform
 	}
 }
+
+// platformList creates a platform for every coin
+// with a known implementation, ordered by coin ID
+func platformList() []blockatlas.Platform {
+	ids := make([]uint, 0, len(coin.Coins))
+	for id := range coin.Coins {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var list []blockatlas.Platform
+	for _, id := range ids {
+		c := coin.Coins[id]
+		name := c.Platform
+		if name == "" {
+			name = c.Handle
+		}
+		factory, ok := platformFactories[name]
+		if !ok {
+			logrus.WithField("coin", c).Warning("No platform implementation")
+			continue
+		}
+		list = append(list, factory(id))
+	}
+	return list
+}
+
+// ByCoin returns the registered platform of a coin or nil
+func ByCoin(id uint) blockatlas.Platform {
+	for _, platform := range Platforms {
+		if platform.Coin().ID == id {
+			return platform
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package platform
+
+import (
+	"github.com/trustwallet/blockatlas/coin"
+	"testing"
+)
+
+func TestPlatformList(t *testing.T) {
+	list := platformList()
+	if len(list) != len(coin.Coins) {
+		t.Errorf("expected a platform for each of %d coins, got %d", len(coin.Coins), len(list))
+	}
+	for _, p := range list {
+		c := p.Coin()
+		if coin.Coins[c.ID].Handle != c.Handle {
+			t.Errorf("platform of %s serves %s", coin.Coins[c.ID].Handle, c.Handle)
+		}
+	}
+}
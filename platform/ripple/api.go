@@ -1,11 +1,13 @@
 package ripple
 
 import (
+	"context"
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/valyala/fastjson"
-	"net/http"
 	"time"
 )
 
@@ -14,8 +16,15 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("ripple.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
+	p.client.RPCURL = viper.GetString("ripple.rpc")
+	// rippled is not an endpoint of the Data API,
+	// its failures are tracked on their own
+	rpcConf := upstream.LoadConfig(p.Coin().Handle)
+	rpcConf.Handle += "_rpc"
+	rpcConf.Endpoints = nil
+	p.client.RPCClient = upstream.NewWithConfig(rpcConf)
 	return nil
 }
 
@@ -24,23 +33,113 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	s, err := p.client.GetTxsOfAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	page, _, err := p.GetTxPageByAddressContext(ctx, address, "")
+	return page, err
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
+
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The cursor is the marker returned by the Data API.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	s, marker, err := p.client.GetTxsOfAddress(ctx, address, cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	txs := make([]blockatlas.Tx, 0)
 	for _, srcTx := range s {
 		tx, ok := Normalize(&srcTx)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		txs = append(txs, tx)
 	}
 
+	return txs, marker, nil
+}
+
+func (p *Platform) GetPendingTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetPendingTxsByAddressContext(context.Background(), address)
+}
+
+// GetPendingTxsByAddressContext returns the payments of an address
+// in the open ledger. The Data API only serves validated ledgers,
+// rippled is asked for the others.
+func (p *Platform) GetPendingTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	srcTxs, err := p.client.GetUnvalidatedTxs(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []blockatlas.Tx
+	for _, srcTx := range srcTxs {
+		if srcTx.Validated || srcTx.Tx.TransactionType != "Payment" {
+			continue
+		}
+		tx, ok := Normalize(&Tx{
+			Hash:        srcTx.Tx.Hash,
+			LedgerIndex: srcTx.Tx.LedgerIndex,
+			Payment:     srcTx.Tx.Payment,
+		})
+		if !ok {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
+		tx.Date = srcTx.Tx.Date + rippleEpoch
+		tx.Status = blockatlas.StatusPending
+		txs = append(txs, tx)
+	}
 	return txs, nil
 }
 
+// rippleEpoch is the Unix time of 2000-01-01
+const rippleEpoch = 946684800
+
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	srcTx, err := p.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, ok := Normalize(srcTx)
+	if !ok {
+		return nil, blockatlas.ErrNotFound
+	}
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	balances, err := p.client.GetBalances(ctx, address)
+	if err != nil {
+		return "", err
+	}
+
+	for _, balance := range balances {
+		if balance.Currency != "XRP" {
+			continue
+		}
+		return blockatlas.ParseAmount(balance.Value, 6)
+	}
+
+	return "0", nil
+}
+
 // Normalize converts a Ripple transaction into the generic model
 func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 	// Only accept XRP payments (typeof tx.amount === 'string')
@@ -52,7 +151,11 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 	if v.Type() != fastjson.TypeString {
 		return tx, false
 	}
-	srcAmount := string(v.GetStringBytes())
+	// XRP amounts are given in drops
+	srcAmount, err := blockatlas.ParseAmount(string(v.GetStringBytes()), 0)
+	if err != nil {
+		return tx, false
+	}
 
 	date, err := time.Parse("2006-01-02T15:04:05-07:00", srcTx.Date)
 	var unix int64
@@ -71,7 +174,7 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 		Fee:   srcTx.Payment.Fee,
 		Block: srcTx.LedgerIndex,
 		Meta:  blockatlas.Transfer{
-			Value: blockatlas.Amount(srcAmount),
+			Value: srcAmount,
 		},
 	}, true
 }
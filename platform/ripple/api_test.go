@@ -2,9 +2,13 @@ package ripple
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -130,3 +134,80 @@ func testNormalize(t *testing.T, _test *test) {
 	}
 }
 
+func TestPlatform_GetPendingTxsByAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "account_tx" ||
+			len(req.Params) != 1 || req.Params[0].Account != "rGSxFjoqmWz54PycrgQBQ5dB6e7TUpMxzq" ||
+			req.Params[0].LedgerIndex != "current" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"status": "success", "transactions": [
+			{"validated": false, "tx": {"TransactionType": "Payment", "Amount": "1000", "Fee": "12",
+				"Account": "rGSxFjoqmWz54PycrgQBQ5dB6e7TUpMxzq", "Destination": "rMQ98K56yXJbDGv49ZSmW51sLn94Xe1mu1",
+				"hash": "A1", "ledger_index": 50000001, "date": 624000000}},
+			{"validated": false, "tx": {"TransactionType": "OfferCreate", "hash": "A2", "ledger_index": 50000001}},
+			{"validated": true, "tx": {"TransactionType": "Payment", "Amount": "2000", "Fee": "12",
+				"Account": "rGSxFjoqmWz54PycrgQBQ5dB6e7TUpMxzq", "Destination": "rMQ98K56yXJbDGv49ZSmW51sLn94Xe1mu1",
+				"hash": "A3", "ledger_index": 50000000, "date": 623999990}}
+		]}}`)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{RPCClient: server.Client(), RPCURL: server.URL}}
+	txs, err := p.GetPendingTxsByAddressContext(context.Background(), "rGSxFjoqmWz54PycrgQBQ5dB6e7TUpMxzq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 {
+		t.Fatalf("expected 1 unvalidated payment, got %v", txs)
+	}
+	tx := txs[0]
+	if tx.ID != "A1" || tx.Status != blockatlas.StatusPending || tx.Date != 1570684800 || tx.Block != 50000001 {
+		t.Errorf("unexpected pending transaction %+v", tx)
+	}
+}
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	// The Data API serves two pages, the second one ends the list
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/"+paymentDst.From+"/transactions" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("marker") {
+		case "":
+			fmt.Fprintf(w, `{"result": "success", "count": 1, "marker": "second", "transactions": [%s]}`, paymentSrc)
+		case "second":
+			fmt.Fprintf(w, `{"result": "success", "count": 1, "transactions": [%s]}`, paymentSrc)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"result": "error", "message": "invalid marker"}`)
+		}
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tests := []struct {
+		cursor string
+		next   string
+	}{
+		{"", "second"},
+		{"second", ""},
+	}
+	for _, test := range tests {
+		txs, next, err := p.GetTxPageByAddressContext(context.Background(), paymentDst.From, test.cursor)
+		if err != nil {
+			t.Fatalf("cursor %q: %v", test.cursor, err)
+		}
+		if len(txs) != 1 || next != test.next {
+			t.Errorf("cursor %q: expected 1 transaction and next %q, got %d and %q",
+				test.cursor, test.next, len(txs), next)
+		}
+	}
+
+	if _, _, err := p.GetTxPageByAddressContext(context.Background(), paymentDst.From, "third"); err != blockatlas.ErrInvalidCursor {
+		t.Errorf("expected invalid cursor, got %v", err)
+	}
+}
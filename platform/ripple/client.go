@@ -1,10 +1,13 @@
 package ripple
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 )
@@ -12,25 +15,138 @@ import (
 type Client struct {
 	HTTPClient *http.Client
 	BaseURL    string
+	RPCClient  *http.Client
+	RPCURL     string
 }
 
-func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
-	uri := fmt.Sprintf("%s/accounts/%s/transactions?type=Payment&result=tesSUCCESS&limit=%d",
+// GetTxsOfAddress returns the transactions of an address
+// starting at marker (if not empty) and the marker of the next page.
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, marker string) ([]Tx, string, error) {
+	query := url.Values{
+		"type":       {"Payment"},
+		"result":     {"tesSUCCESS"},
+		"descending": {"true"},
+		"limit":      {"200"},
+	}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+	uri := fmt.Sprintf("%s/accounts/%s/transactions?%s",
 		c.BaseURL,
 		url.PathEscape(address),
-		200)
-	httpRes, err := c.HTTPClient.Get(uri)
+		query.Encode())
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Error("Ripple: Failed to get transactions")
-		return nil, blockatlas.ErrSourceConn
+		return nil, "", blockatlas.ErrSourceConn
 	}
+	defer httpRes.Body.Close()
 
 	var res Response
 	err = json.NewDecoder(httpRes.Body).Decode(&res)
 
 	if res.Result != "success" {
+		if marker != "" && httpRes.StatusCode == http.StatusBadRequest {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
+		return nil, "", blockatlas.ErrSourceConn
+	}
+
+	return res.Transactions, res.Marker, nil
+}
+
+// GetTx returns a transaction by its hash
+func (c *Client) GetTx(ctx context.Context, hash string) (*Tx, error) {
+	uri := fmt.Sprintf("%s/transactions/%s",
+		c.BaseURL,
+		url.PathEscape(hash))
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Ripple: Failed to get transaction")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	switch httpRes.StatusCode {
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, blockatlas.ErrNotFound
+	}
+
+	var res TxResponse
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+
+	if res.Result != "success" {
+		return nil, blockatlas.ErrSourceConn
+	}
+
+	return &res.Transaction, nil
+}
+
+// GetBalances returns the balances of an address
+// or nil if the account has not been created yet.
+func (c *Client) GetBalances(ctx context.Context, address string) ([]Balance, error) {
+	uri := fmt.Sprintf("%s/accounts/%s/balances",
+		c.BaseURL,
+		url.PathEscape(address))
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Ripple: Failed to get balances")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var res BalancesResponse
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+
+	if res.Result != "success" {
+		return nil, blockatlas.ErrSourceConn
+	}
+
+	return res.Balances, nil
+}
+
+// GetUnvalidatedTxs returns the transactions of an address
+// in the open ledger of rippled, which is not validated yet.
+// Returns nil if no rippled endpoint is configured.
+func (c *Client) GetUnvalidatedTxs(ctx context.Context, address string) ([]RPCTx, error) {
+	if c.RPCURL == "" {
+		return nil, nil
+	}
+	body, err := json.Marshal(RPCRequest{
+		Method: "account_tx",
+		Params: []AccountTxParams{{
+			Account:     address,
+			LedgerIndex: "current",
+			Limit:       20,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// account_tx is read-only, it may be retried
+	req, err := http.NewRequestWithContext(upstream.Idempotent(ctx),
+		http.MethodPost, c.RPCURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpRes, err := c.RPCClient.Do(req)
+	if err != nil {
+		logrus.WithError(err).Error("Ripple: Failed to get unvalidated transactions")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	var res AccountTxResponse
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+
+	if res.Result.Status != "success" {
 		return nil, blockatlas.ErrSourceConn
 	}
 
-	return res.Transactions, nil
+	return res.Result.Transactions, nil
 }
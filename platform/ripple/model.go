@@ -6,9 +6,9 @@ import (
 )
 
 type Amount struct {
-	Value    blockatlas.Amount `json:"string"`
-	Currency string        `json:"string"`
-	Issuer   string        `json:"string"`
+	Value    blockatlas.Amount `json:"value"`
+	Currency string            `json:"currency"`
+	Issuer   string            `json:"issuer"`
 }
 
 type Response struct {
@@ -18,6 +18,22 @@ type Response struct {
 	Transactions []Tx   `json:"transactions"`
 }
 
+type TxResponse struct {
+	Result      string `json:"result"`
+	Transaction Tx     `json:"transaction"`
+}
+
+type BalancesResponse struct {
+	Result   string    `json:"result"`
+	Balances []Balance `json:"balances"`
+}
+
+type Balance struct {
+	Currency     string `json:"currency"`
+	Counterparty string `json:"counterparty"`
+	Value        string `json:"value"`
+}
+
 type Tx struct {
 	Hash        string  `json:"hash"`
 	Date        string  `json:"date"`
@@ -27,7 +43,7 @@ type Tx struct {
 }
 
 type Payment struct {
-	TransactionType string          `json:"string"`
+	TransactionType string          `json:"TransactionType"`
 	Flags           uint64          `json:"Flags"`
 	Sequence        uint64          `json:"Sequence"`
 	Amount          json.RawMessage `json:"Amount"`
@@ -37,3 +53,36 @@ type Payment struct {
 	Account         string          `json:"Account"`
 	Destination     string          `json:"Destination"`
 }
+
+// RPCRequest is a rippled JSON-RPC request
+type RPCRequest struct {
+	Method string            `json:"method"`
+	Params []AccountTxParams `json:"params"`
+}
+
+type AccountTxParams struct {
+	Account     string `json:"account"`
+	LedgerIndex string `json:"ledger_index"`
+	Limit       int    `json:"limit"`
+}
+
+type AccountTxResponse struct {
+	Result struct {
+		Status       string  `json:"status"`
+		Transactions []RPCTx `json:"transactions"`
+	} `json:"result"`
+}
+
+// RPCTx is a transaction returned by rippled
+type RPCTx struct {
+	Tx        RPCPayment `json:"tx"`
+	Validated bool       `json:"validated"`
+}
+
+type RPCPayment struct {
+	Payment
+	Hash        string `json:"hash"`
+	LedgerIndex uint64 `json:"ledger_index"`
+	// Seconds since the Ripple epoch
+	Date int64 `json:"date"`
+}
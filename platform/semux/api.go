@@ -1,10 +1,12 @@
 package semux
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"strconv"
 )
 
@@ -13,7 +15,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("semux.api")
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	p.client.Init()
 	return nil
 }
@@ -23,20 +26,78 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	s, err := p.client.GetTxsOfAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	page, _, err := p.GetTxPageByAddressContext(ctx, address, "")
+	return page, err
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
+
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The cursor is the (exclusive) index of the newest transaction to return.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	var end uint64
+	if cursor == "" {
+		account, err := p.client.GetAccount(ctx, address)
+		if err != nil {
+			return nil, "", err
+		}
+		end = account.TransactionCount
+	} else {
+		var err error
+		end, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
+	}
+
+	if end == 0 {
+		return nil, "", nil
+	}
+
+	start := uint64(0)
+	if end > blockatlas.TxPerPage {
+		start = end - blockatlas.TxPerPage
+	}
+
+	s, err := p.client.GetTxsOfAddress(ctx, address, start, end)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var txs []blockatlas.Tx
 	for _, srcTx := range s {
 		tx, err := Normalize(&srcTx)
-		if err == nil {
-			txs = append(txs, tx)
+		if err != nil {
+			metrics.Dropped(p.Coin().Handle)
+			continue
 		}
+		txs = append(txs, tx)
 	}
 
-	return txs, nil
+	var next string
+	if start > 0 {
+		next = strconv.FormatUint(start, 10)
+	}
+
+	return txs, next, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return account.Available, nil
 }
 
 // Normalize converts a semux transaction into the generic model
@@ -53,7 +114,7 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, err error) {
 		return blockatlas.Tx{}, err
 	}
 
-	return blockatlas.Tx{
+	tx = blockatlas.Tx{
 		ID:    srcTx.Hash,
 		Coin:  coin.SEM,
 		Date:  date / 1000,
@@ -61,8 +122,24 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, err error) {
 		To:    srcTx.To,
 		Fee:   srcTx.Fee,
 		Block: blockNumber,
-		Meta: blockatlas.Transfer{
+	}
+
+	// Votes lock funds for the delegate they are sent to
+	switch srcTx.Type {
+	case "VOTE":
+		tx.Meta = blockatlas.Delegate{
+			Validator: srcTx.To,
+			Value:     srcTx.Value,
+		}
+	case "UNVOTE":
+		tx.Meta = blockatlas.Undelegate{
+			Validator: srcTx.To,
+			Value:     srcTx.Value,
+		}
+	default:
+		tx.Meta = blockatlas.Transfer{
 			Value: srcTx.Value,
-		},
-	}, nil
+		}
+	}
+	return tx, nil
 }
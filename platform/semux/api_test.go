@@ -2,9 +2,13 @@ package semux
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -97,3 +101,55 @@ func TestNormalize(t *testing.T) {
 		t.Error("basic: tx don't equal")
 	}
 }
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	// The account has 3797 transactions, pages are requested by index range
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account":
+			fmt.Fprint(w, getAccountResponseStr)
+		case "/account/transactions":
+			requests = append(requests, r.URL.Query().Get("start")+"-"+r.URL.Query().Get("end"))
+			fmt.Fprint(w, getAccountTransactionsResponseStr)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tests := []struct {
+		cursor string
+		rng    string
+		next   string
+	}{
+		{"", "3772-3797", "3772"},
+		{"10", "0-10", ""},
+		{"0", "", ""},
+	}
+	for _, test := range tests {
+		requests = nil
+		page, next, err := p.GetTxPageByAddressContext(context.Background(), basicDst.From, test.cursor)
+		if err != nil {
+			t.Fatalf("cursor %q: %v", test.cursor, err)
+		}
+		if next != test.next {
+			t.Errorf("cursor %q: expected next %q, got %q", test.cursor, test.next, next)
+		}
+		if test.rng == "" {
+			if len(requests) != 0 || len(page) != 0 {
+				t.Errorf("cursor %q: expected no transactions, got %v", test.cursor, requests)
+			}
+		} else if len(requests) != 1 || requests[0] != test.rng || len(page) != 1 {
+			t.Errorf("cursor %q: expected range %s, got %v", test.cursor, test.rng, requests)
+		}
+	}
+
+	for _, cursor := range []string{"last", "-10"} {
+		_, _, err := p.GetTxPageByAddressContext(context.Background(), basicDst.From, cursor)
+		if err != blockatlas.ErrInvalidCursor {
+			t.Errorf("cursor %q: expected invalid cursor, got %v", cursor, err)
+		}
+	}
+}
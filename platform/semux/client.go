@@ -1,27 +1,32 @@
 package semux
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/ybbus/jsonrpc"
 	"net/http"
 	"net/url"
 )
 
 type Client struct {
-	BaseURL   string
-	rpcClient jsonrpc.RPCClient
+	BaseURL    string
+	HTTPClient *http.Client
+	rpcClient  jsonrpc.RPCClient
 }
 
 func (c *Client) Init() {
-	c.rpcClient = jsonrpc.NewClient(c.BaseURL)
+	c.rpcClient = jsonrpc.NewClientWithOpts(c.BaseURL, &jsonrpc.RPCClientOpts{
+		HTTPClient: c.HTTPClient,
+	})
 }
 
-func (c *Client) GetAccount(address string) (account Account, err error) {
+func (c *Client) GetAccount(ctx context.Context, address string) (account Account, err error) {
 	path := fmt.Sprintf("%s/account?address=%s", c.BaseURL, url.PathEscape(address))
 
-	res, err := http.Get(path)
+	res, err := upstream.Get(ctx, c.HTTPClient, path)
 	if err != nil {
 		return Account{}, err
 	}
@@ -31,27 +36,18 @@ func (c *Client) GetAccount(address string) (account Account, err error) {
 	dec := json.NewDecoder(res.Body)
 	err = dec.Decode(&getAccountResponse)
 	if err != nil {
-		return Account{}, err
+		return Account{}, blockatlas.ErrDecode(err)
 	}
 
 	return getAccountResponse.Result, nil
 }
 
-func (c *Client) GetTxsOfAddress(address string) (txs []Tx, err error) {
-	account, err := c.GetAccount(address)
-	if err != nil {
-		return nil, err
-	}
-
-	start := uint64(0)
-	if account.TransactionCount > blockatlas.TxPerPage {
-		start = account.TransactionCount - blockatlas.TxPerPage
-	}
-	end := start + blockatlas.TxPerPage
-
+// GetTxsOfAddress returns the transfers of an address
+// with an index in the range [start, end)
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, start, end uint64) (txs []Tx, err error) {
 	path := fmt.Sprintf("%s/account/transactions?address=%s&start=%d&end=%d", c.BaseURL, url.PathEscape(address), start, end)
 
-	res, err := http.Get(path)
+	res, err := upstream.Get(ctx, c.HTTPClient, path)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +57,7 @@ func (c *Client) GetTxsOfAddress(address string) (txs []Tx, err error) {
 	dec := json.NewDecoder(res.Body)
 	err = dec.Decode(&getAccountTransactionsResponse)
 	if err != nil {
-		return nil, err
+		return nil, blockatlas.ErrDecode(err)
 	}
 
 	filter := func(tx Tx) bool { return tx.Type == "TRANSFER" }
@@ -0,0 +1,49 @@
+package stellar
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+)
+
+// versionAccountID is the version byte of
+// strkeys encoding an ed25519 public key ("G...")
+const versionAccountID = 6 << 3
+
+var strkeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CheckAddress verifies the version byte and the CRC16 checksum
+// of an account ID strkey and returns it in canonical upper-case.
+func CheckAddress(address string) (string, error) {
+	address = strings.ToUpper(address)
+	raw, err := strkeyEncoding.DecodeString(address)
+	if err != nil {
+		return "", fmt.Errorf("not a strkey: %s", address)
+	}
+	// Version byte, 32 byte key, 2 byte checksum
+	if len(raw) != 35 || raw[0] != versionAccountID {
+		return "", fmt.Errorf("not an account ID: %s", address)
+	}
+	payload := raw[:33]
+	sum := crc16(payload)
+	if raw[33] != byte(sum) || raw[34] != byte(sum>>8) {
+		return "", fmt.Errorf("invalid checksum: %s", address)
+	}
+	return address, nil
+}
+
+// crc16 computes the CRC-16/XMODEM checksum
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -1,12 +1,12 @@
 package stellar
 
 import (
+	"context"
 	"fmt"
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"github.com/trustwallet/blockatlas/util"
-	"net/http"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"strconv"
 	"time"
 )
@@ -18,10 +18,8 @@ type Platform struct {
 
 func (p *Platform) Init() error {
 	handle := coin.Coins[p.CoinIndex].Handle
-	p.client.API = viper.GetString(fmt.Sprintf("%s.api", handle))
-	p.client.HTTP = &http.Client{
-		Timeout: 2 * time.Second,
-	}
+	p.client.API = upstream.URL(handle)
+	p.client.HTTP = upstream.New(handle)
 	return nil
 }
 
@@ -29,22 +27,154 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[p.CoinIndex]
 }
 
+// ValidateAddress checks the strkey encoding of an account ID
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	canonical, err := CheckAddress(address)
+	if err != nil {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return canonical, nil
+}
+
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	payments, err := p.client.GetTxsOfAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	page, _, err := p.GetTxPageByAddressContext(ctx, address, "")
+	return page, err
+}
+
+func (p *Platform) GetTxPageByAddress(address string, cursor string) (blockatlas.TxPage, string, error) {
+	return p.GetTxPageByAddressContext(context.Background(), address, cursor)
+}
+
+// GetTxPageByAddressContext returns a page of transactions of an address.
+// The cursor is the paging token of the last payment seen.
+func (p *Platform) GetTxPageByAddressContext(ctx context.Context, address string, cursor string) (blockatlas.TxPage, string, error) {
+	if cursor != "" {
+		if _, err := strconv.ParseUint(cursor, 10, 64); err != nil {
+			return nil, "", blockatlas.ErrInvalidCursor
+		}
+	}
+
+	payments, err := p.client.GetTxsOfAddress(ctx, address, cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var txs []blockatlas.Tx
 	for _, payment := range payments {
 		tx, ok := Normalize(&payment, p.CoinIndex)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		txs = append(txs, tx)
 	}
 
-	return txs, nil
+	var next string
+	if len(payments) >= blockatlas.TxPerPage {
+		next = payments[len(payments)-1].PagingToken
+	}
+
+	return txs, next, nil
+}
+
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+// GetTxContext returns a transaction with an operation
+// per supported payment
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	payments, err := p.client.GetPaymentsOfTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx blockatlas.Tx
+	var ops []blockatlas.Op
+	for _, payment := range payments {
+		paymentTx, ok := Normalize(&payment, p.CoinIndex)
+		if !ok {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
+		if len(ops) == 0 {
+			tx = paymentTx
+		}
+		ops = append(ops, blockatlas.Op{From: paymentTx.From, To: paymentTx.To, Meta: paymentTx.Meta})
+	}
+	if len(ops) == 0 {
+		return nil, blockatlas.ErrNotFound
+	}
+	tx.SetOps(ops)
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	// Accounts that have not been funded yet hold nothing
+	if account == nil {
+		return "0", nil
+	}
+
+	for _, balance := range account.Balances {
+		if balance.AssetType != "native" {
+			continue
+		}
+		return blockatlas.ParseAmount(balance.Balance, p.Coin().Decimals)
+	}
+
+	return "0", nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, nil
+	}
+
+	var tokens []blockatlas.Token
+	for _, balance := range account.Balances {
+		if balance.AssetType == "native" {
+			continue
+		}
+		if token, ok := NormalizeToken(&balance, p.Coin().Decimals); ok {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// NormalizeToken converts a Stellar-based trustline into the generic model
+func NormalizeToken(balance *Balance, decimals uint) (token blockatlas.Token, ok bool) {
+	value, err := blockatlas.ParseAmount(balance.Balance, decimals)
+	if err != nil {
+		return token, false
+	}
+	return blockatlas.Token{
+		Name:     balance.AssetCode,
+		Symbol:   balance.AssetCode,
+		TokenID:  fmt.Sprintf("%s-%s", balance.AssetCode, balance.AssetIssuer),
+		Decimals: decimals,
+		Balance:  value,
+	}, true
 }
 
 // Normalize converts a Stellar-based transaction into the generic model
@@ -67,13 +197,15 @@ func Normalize(payment *Payment, nativeCoinIndex uint) (tx blockatlas.Tx, ok boo
 	if err != nil {
 		return tx, false
 	}
-	var value, from, to string
+	decimals := coin.Coins[nativeCoinIndex].Decimals
+	var value blockatlas.Amount
+	var from, to string
 	if payment.Amount != "" {
-		value, err = util.DecimalToSatoshis(payment.Amount)
+		value, err = blockatlas.ParseAmount(payment.Amount, decimals)
 		from = payment.From
 		to = payment.To
 	} else if payment.StartingBalance != "" {
-		value, err = util.DecimalToSatoshis(payment.StartingBalance)
+		value, err = blockatlas.ParseAmount(payment.StartingBalance, decimals)
 		from = payment.Funder
 		to = payment.Account
 	} else {
@@ -93,7 +225,7 @@ func Normalize(payment *Payment, nativeCoinIndex uint) (tx blockatlas.Tx, ok boo
 		Date:  date.Unix(),
 		Block: id,
 		Meta:  blockatlas.Transfer{
-			Value: blockatlas.Amount(value),
+			Value: value,
 		},
 	}, true
 }
@@ -2,9 +2,16 @@ package stellar
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +121,141 @@ func testNormalize(t *testing.T, _test *test) {
 		t.Error(_test.name + ": tx don't equal")
 	}
 }
+
+const trustlineSrc = `
+{
+	"balance": "12.5000000",
+	"limit": "922337203685.4775807",
+	"asset_type": "credit_alphanum4",
+	"asset_code": "MOBI",
+	"asset_issuer": "GA6HCMBLTZS5VYYBCATRBRZ3BZJMAFUDKYYF6AH6MVCMGWMRDNSWJPIH"
+}
+`
+
+var trustlineDst = blockatlas.Token{
+	Name:     "MOBI",
+	Symbol:   "MOBI",
+	TokenID:  "MOBI-GA6HCMBLTZS5VYYBCATRBRZ3BZJMAFUDKYYF6AH6MVCMGWMRDNSWJPIH",
+	Decimals: 7,
+	Balance:  "125000000",
+}
+
+func TestNormalizeToken(t *testing.T) {
+	var balance Balance
+	err := json.Unmarshal([]byte(trustlineSrc), &balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, ok := NormalizeToken(&balance, 7)
+	if !ok {
+		t.Fatal("token could not be normalized")
+	}
+
+	if !reflect.DeepEqual(token, trustlineDst) {
+		t.Errorf("token don't equal: %+v", token)
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	expected := "GAX3BRBNB5WTJ2GNEFFH7A4CZKT2FORYABDDBZR5FIIT3P7FLS2EFOZZ"
+	for _, input := range []string{expected, strings.ToLower(expected)} {
+		actual, err := CheckAddress(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err)
+		} else if actual != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, actual)
+		}
+	}
+
+	for _, input := range []string{
+		"GAX3BRBNB5WTJ2GNEFFH7A4CZKT2FORYABDDBZR5FIIT3P7FLS2EFOZA",
+		"GAX3BRBNB5WTJ2GNEFFH7A4CZKT2FORYABDDBZR5FIIT3P7FLS2EFOZ",
+		"SAX3BRBNB5WTJ2GNEFFH7A4CZKT2FORYABDDBZR5FIIT3P7FLS2EFOZZ",
+	} {
+		if _, err := CheckAddress(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestPlatform_GetTx_Payments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/a596dc910bae20b5bbe64aa7aa3f42acbd55769b98307878f5ad095e994bc9cf/payments" {
+			http.NotFound(w, r)
+			return
+		}
+		second := strings.Replace(transferSrc, "GAX3BRBNB5WTJ2GNEFFH7A4CZKT2FORYABDDBZR5FIIT3P7FLS2EFOZZ",
+			"GBEZOC5U4TVH7ZY5N3FLYHTCZSI6VFGTULG7PBITLF5ZEBPJXFT46YZM", 1)
+		fmt.Fprintf(w, `{"_embedded": {"records": [%s, %s]}}`, transferSrc, second)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTP: server.Client(), API: server.URL}, CoinIndex: coin.XLM}
+	tx, err := p.GetTxContext(context.Background(), transferDst.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.To != transferDst.To || len(tx.Ops) != 2 {
+		t.Fatalf("expected a transaction with 2 payments, got %+v", tx)
+	}
+	if tx.Ops[1].To != "GBEZOC5U4TVH7ZY5N3FLYHTCZSI6VFGTULG7PBITLF5ZEBPJXFT46YZM" {
+		t.Errorf("unexpected second payment %+v", tx.Ops[1])
+	}
+}
+
+func TestPlatform_GetTxPageByAddress(t *testing.T) {
+	// The account has 30 payments with paging tokens 1 to 30
+	const address = "GDKIJJIKXLOM2NRMPNQZUUYK24ZPVFC6426GZAEP3KUK6KEJLACCWNMX"
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/"+address+"/payments" || r.URL.Query().Get("order") != "desc" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		token := 31
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			token, _ = strconv.Atoi(cursor)
+		}
+		var records []string
+		for token--; token > 0 && len(records) < limit; token-- {
+			records = append(records, strings.Replace(transferSrc,
+				`"paging_token": "25008572362395649"`, fmt.Sprintf(`"paging_token": "%d"`, token), 1))
+		}
+		fmt.Fprintf(w, `{"_embedded": {"records": [%s]}}`, strings.Join(records, ","))
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTP: server.Client(), API: server.URL}, CoinIndex: coin.XLM}
+	tests := []struct {
+		cursor string
+		txs    int
+		next   string
+	}{
+		{"", 25, "6"},
+		{"6", 5, ""},
+	}
+	for _, test := range tests {
+		page, next, err := p.GetTxPageByAddressContext(context.Background(), address, test.cursor)
+		if err != nil {
+			t.Fatalf("cursor %q: %v", test.cursor, err)
+		}
+		if len(page) != test.txs || next != test.next {
+			t.Errorf("cursor %q: expected %d transactions and next %q, got %d and %q",
+				test.cursor, test.txs, test.next, len(page), next)
+		}
+	}
+
+	requests = 0
+	for _, cursor := range []string{"now", "-6"} {
+		_, _, err := p.GetTxPageByAddressContext(context.Background(), address, cursor)
+		if err != blockatlas.ErrInvalidCursor {
+			t.Errorf("cursor %q: expected invalid cursor, got %v", cursor, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests for invalid cursors, got %d", requests)
+	}
+}
@@ -1,10 +1,14 @@
 package stellar
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 type Client struct {
@@ -12,11 +16,20 @@ type Client struct {
 	API        string
 }
 
-func (c *Client) GetTxsOfAddress(address string) (txs []Payment, err error) {
-	path := fmt.Sprintf("%s/accounts/%s/payments?order=desc&limit=25",
-		c.API, url.PathEscape(address))
+// GetTxsOfAddress returns the payments of an address older than
+// the payment with the given paging token (if cursor is not empty).
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, cursor string) (txs []Payment, err error) {
+	query := url.Values{
+		"order": {"desc"},
+		"limit": {strconv.Itoa(blockatlas.TxPerPage)},
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	path := fmt.Sprintf("%s/accounts/%s/payments?%s",
+		c.API, url.PathEscape(address), query.Encode())
 
-	res, err := http.Get(path)
+	res, err := upstream.Get(ctx, c.HTTP, path)
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +38,67 @@ func (c *Client) GetTxsOfAddress(address string) (txs []Payment, err error) {
 	var payments PaymentsPage
 	dec := json.NewDecoder(res.Body)
 	err = dec.Decode(&payments)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return payments.Embedded.Records, nil
+}
+
+// GetPaymentsOfTx returns the payments of a transaction
+// or ErrNotFound if the transaction does not exist.
+func (c *Client) GetPaymentsOfTx(ctx context.Context, hash string) ([]Payment, error) {
+	path := fmt.Sprintf("%s/transactions/%s/payments", c.API, url.PathEscape(hash))
+
+	res, err := upstream.Get(ctx, c.HTTP, path)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, blockatlas.ErrNotFound
+	default:
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	var payments PaymentsPage
+	err = json.NewDecoder(res.Body).Decode(&payments)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
 
 	return payments.Embedded.Records, nil
 }
+
+// GetAccount returns the account of an address
+// or nil if the account has not been created yet.
+func (c *Client) GetAccount(ctx context.Context, address string) (*Account, error) {
+	path := fmt.Sprintf("%s/accounts/%s", c.API, url.PathEscape(address))
+
+	res, err := upstream.Get(ctx, c.HTTP, path)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	account := new(Account)
+	err = json.NewDecoder(res.Body).Decode(account)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return account, nil
+}
@@ -7,6 +7,21 @@ type PaymentsPage struct {
 	} `json:"_embedded"`
 }
 
+// Account model returned by Horizon
+type Account struct {
+	ID       string    `json:"id"`
+	Sequence string    `json:"sequence"`
+	Balances []Balance `json:"balances"`
+}
+
+// Balance of an asset held by an account
+type Balance struct {
+	Balance     string `json:"balance"`
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+}
+
 // Payment model returned by Horizon
 type Payment struct {
 	ID          string `json:"id"`
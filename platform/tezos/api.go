@@ -1,10 +1,11 @@
 package tezos
 
 import (
-	"github.com/spf13/viper"
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"net/http"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"time"
 )
 
@@ -13,8 +14,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("tezos.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -23,15 +24,25 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	s, err := p.client.GetTxsOfAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	s, err := p.client.GetTxsOfAddress(ctx, address, "Transaction")
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := p.client.GetTxsOfAddress(ctx, address, "Delegation")
 	if err != nil {
 		return nil, err
 	}
+	s = append(s, delegations...)
 
 	txs := make([]blockatlas.Tx, 0)
 	for _, srcTx := range s {
 		tx, ok := Normalize(&srcTx)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		txs = append(txs, tx)
@@ -40,6 +51,35 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	return txs, nil
 }
 
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	srcTx, err := p.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, ok := Normalize(srcTx)
+	if !ok {
+		return nil, blockatlas.ErrNotFound
+	}
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return account.Balance, nil
+}
+
 // Normalize converts a Tezos transaction into the generic model
 func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 	if srcTx.Type.Kind != "manager" {
@@ -59,9 +99,6 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 		unix = date.Unix()
 	}
 
-	if op.Kind != "transaction" {
-		return tx, false
-	}
 	var status, errMsg string
 	if !op.Failed {
 		status = blockatlas.StatusCompleted
@@ -69,18 +106,39 @@ func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
 		status = blockatlas.StatusFailed
 		errMsg = "transaction failed"
 	}
-	return blockatlas.Tx{
+	tx = blockatlas.Tx{
 		ID:    srcTx.Hash,
 		Coin:  coin.XTZ,
 		Date:  unix,
 		From:  op.Src.Tz,
-		To:    op.Dest.Tz,
 		Fee:   op.Fee,
 		Block: op.OpLevel,
-		Meta:   blockatlas.Transfer{
-			Value: op.Amount,
-		},
 		Status: status,
 		Error:  errMsg,
-	}, true
+	}
+
+	switch op.Kind {
+	case "transaction":
+		tx.To = op.Dest.Tz
+		tx.Meta = blockatlas.Transfer{
+			Value: op.Amount,
+		}
+	case "delegation":
+		// The whole balance is delegated, no funds are moved.
+		// Delegations without a delegate withdraw the current one
+		if op.Delegate.Tz == "" {
+			tx.Meta = blockatlas.Undelegate{
+				Value: "0",
+			}
+		} else {
+			tx.To = op.Delegate.Tz
+			tx.Meta = blockatlas.Delegate{
+				Validator: op.Delegate.Tz,
+				Value:     "0",
+			}
+		}
+	default:
+		return tx, false
+	}
+	return tx, true
 }
@@ -85,3 +85,80 @@ func TestNormalize(t *testing.T) {
 		t.Error("basic: tx don't equal")
 	}
 }
+
+const delegationSrc = `
+{
+	"hash": "onqGEgwyYktyZvXhR7Bef1YU3TRGo5LdZvMxqEkP9Q5MPHPFkWB",
+	"block_hash": "BLPPz3y3mVNgWd7Ge3QNLDFe1zFg5eENDfuUQYWYTAPNhZkUcr6",
+	"network_hash": "NetXdQprcVkpaWU",
+	"type": {
+		"kind": "manager",
+		"source": {
+			"tz": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"
+		},
+		"operations": [
+			{
+				"kind": "delegation",
+				"src": {
+					"tz": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"
+				},
+				"delegate": {
+					"tz": "tz1TDSmoZXwVevLTEvKCTHWpomG76oC9S2fJ"
+				},
+				"failed": false,
+				"internal": false,
+				"counter": 11081,
+				"fee": 1300,
+				"gas_limit": "10100",
+				"storage_limit": "0",
+				"op_level": 393184,
+				"timestamp": "2019-04-12T22:51:04Z"
+			}
+		]
+	}
+}
+`
+
+var delegationDst = blockatlas.Tx{
+	ID:    "onqGEgwyYktyZvXhR7Bef1YU3TRGo5LdZvMxqEkP9Q5MPHPFkWB",
+	Coin:  coin.XTZ,
+	From:  "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
+	To:    "tz1TDSmoZXwVevLTEvKCTHWpomG76oC9S2fJ",
+	Fee:   "1300",
+	Date:  1555109464,
+	Block: 393184,
+	Meta: blockatlas.Delegate{
+		Validator: "tz1TDSmoZXwVevLTEvKCTHWpomG76oC9S2fJ",
+		Value:     "0",
+	},
+}
+
+func TestNormalizeDelegation(t *testing.T) {
+	var srcTx Tx
+	err := json.Unmarshal([]byte(delegationSrc), &srcTx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	tx, ok := Normalize(&srcTx)
+	if !ok {
+		t.Errorf("delegation: tx could not be normalized")
+	}
+
+	resJSON, err := json.Marshal(&tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstJSON, err := json.Marshal(&delegationDst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(resJSON, dstJSON) {
+		println(string(resJSON))
+		println(string(dstJSON))
+		t.Error("delegation: tx don't equal")
+	}
+}
@@ -1,10 +1,12 @@
 package tezos
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 )
@@ -16,17 +18,21 @@ type Client struct {
 	BaseURL    string
 }
 
-func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
-	uri := fmt.Sprintf("%s/operations/%s?type=Transaction",
-		c.BaseURL, url.PathEscape(address))
-	httpRes, err := c.HTTPClient.Get(uri)
+// GetTxsOfAddress returns the operations of an address
+// of the given TzScan type (e.g. "Transaction", "Delegation")
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string, opType string) ([]Tx, error) {
+	uri := fmt.Sprintf("%s/operations/%s?%s",
+		c.BaseURL,
+		url.PathEscape(address),
+		url.Values{"type": {opType}}.Encode())
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Error("Tezos: Failed to get transactions")
 		return nil, blockatlas.ErrSourceConn
 	}
 
 	if httpRes.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http status %s", httpRes.Status)
+		return nil, blockatlas.ErrStatus(httpRes.StatusCode)
 	}
 
 	var res []Tx
@@ -34,3 +40,54 @@ func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
 
 	return res, nil
 }
+
+func (c *Client) GetTx(ctx context.Context, hash string) (*Tx, error) {
+	uri := fmt.Sprintf("%s/operation/%s",
+		c.BaseURL, url.PathEscape(hash))
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Tezos: Failed to get transaction")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	switch httpRes.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, blockatlas.ErrNotFound
+	default:
+		return nil, blockatlas.ErrStatus(httpRes.StatusCode)
+	}
+
+	tx := new(Tx)
+	err = json.NewDecoder(httpRes.Body).Decode(tx)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return tx, nil
+}
+
+func (c *Client) GetAccount(ctx context.Context, address string) (*Account, error) {
+	uri := fmt.Sprintf("%s/node_account/%s",
+		c.BaseURL, url.PathEscape(address))
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Tezos: Failed to get account")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return nil, blockatlas.ErrStatus(httpRes.StatusCode)
+	}
+
+	account := new(Account)
+	err = json.NewDecoder(httpRes.Body).Decode(account)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return account, nil
+}
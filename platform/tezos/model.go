@@ -17,11 +17,12 @@ type Manager struct {
 	Operations []Operation `json:"operations"`
 }
 
-// Operation is a Tezos transfer operation
+// Operation is a Tezos transfer or delegation operation
 type Operation struct {
 	Kind         string        `json:"kind"`
 	Src          Address       `json:"src"`
 	Dest         Address       `json:"destination"`
+	Delegate     Address       `json:"delegate"`
 	Amount       blockatlas.Amount `json:"amount"`
 	Failed       bool          `json:"failed"`
 	Internal     bool          `json:"internal"`
@@ -34,6 +35,12 @@ type Operation struct {
 	Timestamp    string        `json:"timestamp"`
 }
 
+// Account is a Tezos account as known by the node
+type Account struct {
+	Hash    string            `json:"hash"`
+	Balance blockatlas.Amount `json:"balance"`
+}
+
 // Address is a Tezos address object
 type Address struct {
 	Tz string `json:"tz"`
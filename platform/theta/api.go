@@ -1,10 +1,11 @@
 package theta
 
 import (
-	"github.com/spf13/viper"
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"net/http"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"strconv"
 )
 
@@ -13,8 +14,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("theta.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -23,23 +24,39 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
 	// Endpoint supports queries without token query parameter
-	return p.GetTokenTxsByAddress(address, "")
+	return p.GetTokenTxsByAddressContext(ctx, address, "")
 }
 
 func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
-	trx, err := p.client.FetchAddressTransactions(address)
+	return p.GetTokenTxsByAddressContext(context.Background(), address, token)
+}
+
+func (p *Platform) GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (blockatlas.TxPage, error) {
+	trx, err := p.client.FetchAddressTransactions(ctx, address)
 	if err != nil {
 		return nil, err
 	}
 
 	var txs []blockatlas.Tx
 	for _, tr := range trx {
-		if tr.Type != SendTransaction {
-			continue
+		var tx blockatlas.Tx
+		var ok bool
+		switch tr.Type {
+		case SendTransaction:
+			tx, ok = Normalize(&tr, address, token)
+		case DepositStakeTransaction, WithdrawStakeTransaction:
+			// Only THETA can be staked
+			if token == "" {
+				tx, ok = NormalizeStake(&tr)
+			}
 		}
-		tx, ok := Normalize(&tr, address, token)
 		if !ok {
+			metrics.Dropped(p.Coin().Handle)
 			continue
 		}
 		txs = append(txs, tx)
@@ -48,9 +65,53 @@ func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatla
 	return txs, nil
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.FetchAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if account.Balance.Thetawei == "" {
+		return "0", nil
+	}
+	return blockatlas.Amount(account.Balance.Thetawei), nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	account, err := p.client.FetchAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	balance := account.Balance.Tfuelwei
+	if balance == "" {
+		balance = "0"
+	}
+	return []blockatlas.Token{{
+		Name:     "Theta Fuel",
+		Symbol:   "TFUEL",
+		TokenID:  "tfuel",
+		Decimals: 18,
+		Balance:  blockatlas.Amount(balance),
+	}}, nil
+}
+
+// Normalize converts a Theta send transaction into the generic model.
+// Every output of the requested currency becomes an operation.
 func Normalize(trx *Tx, address, token string) (tx blockatlas.Tx, ok bool) {
+	if address == "" || len(trx.Data.Inputs) == 0 {
+		return tx, false
+	}
+
 	time, _ := strconv.ParseInt(trx.Timestamp, 10, 64)
 	block, _ := strconv.ParseUint(trx.BlockHeight, 10, 64)
+	sequence, _ := strconv.ParseUint(trx.Data.Inputs[0].Sequence, 10, 64)
 
 	tx = blockatlas.Tx{
 		ID: trx.Hash,
@@ -58,46 +119,91 @@ func Normalize(trx *Tx, address, token string) (tx blockatlas.Tx, ok bool) {
 		Fee: blockatlas.Amount(trx.Data.Fee.Tfuelwei),
 		Date:  time,
 		Block: block,
-		Sequence: block,
+		Sequence: sequence,
 	}
 
-	input := trx.Data.Inputs[0]
-	output := trx.Data.Outputs[0]
-	sequence, _ := strconv.ParseUint(input.Sequence, 10, 64)
-
-	// Condition for transfer THETA trnafer
-	if address != "" && token == "" && output.Coins.Tfuelwei == "0" {
-		tx.From = input.Address
-		tx.To = output.Address
-		tx.Sequence = sequence
-		tx.Type = blockatlas.TxTransfer
-		tx.Meta = blockatlas.Transfer{
-			Value: blockatlas.Amount(output.Coins.Thetawei),
+	var ops []blockatlas.Op
+	for i, output := range trx.Data.Outputs {
+		// Inputs and outputs are paired if there are as many of both
+		input := trx.Data.Inputs[0]
+		if i < len(trx.Data.Inputs) {
+			input = trx.Data.Inputs[i]
 		}
-
-		return tx, true
-	}
-
-	// Condition for transfer Theta Fuel (TFUEL)
-	if address != "" && token == "tfuel" && output.Coins.Thetawei == "0" {
 		from := input.Address
 		to := output.Address
-		tx.From = from
-		tx.To = to
-		tx.Sequence = sequence
-		tx.Type = blockatlas.TxNativeTokenTransfer
-		tx.Meta = blockatlas.NativeTokenTransfer{
-			Name: "Theta Fuel",
-			Symbol: "TFUEL",
-			TokenID: "tfuel",
-			Decimals: 18,
-			Value: blockatlas.Amount(output.Coins.Tfuelwei),
-			From: from,
-			To: to,
+
+		// Condition for transfer THETA trnafer
+		if token == "" && output.Coins.Tfuelwei == "0" {
+			ops = append(ops, blockatlas.Op{
+				From: from,
+				To: to,
+				Meta: blockatlas.Transfer{
+					Value: blockatlas.Amount(output.Coins.Thetawei),
+				},
+			})
+		}
+
+		// Condition for transfer Theta Fuel (TFUEL)
+		if token == "tfuel" && output.Coins.Thetawei == "0" {
+			ops = append(ops, blockatlas.Op{
+				From: from,
+				To: to,
+				Meta: blockatlas.NativeTokenTransfer{
+					Name: "Theta Fuel",
+					Symbol: "TFUEL",
+					TokenID: "tfuel",
+					Decimals: 18,
+					Value: blockatlas.Amount(output.Coins.Tfuelwei),
+					From: from,
+					To: to,
+				},
+			})
 		}
+	}
+	if len(ops) == 0 {
+		return tx, false
+	}
 
-		return tx, true
+	tx.SetOps(ops)
+	return tx, true
+}
+
+// NormalizeStake converts a Theta stake deposit or withdrawal into the generic model
+func NormalizeStake(trx *Tx) (tx blockatlas.Tx, ok bool) {
+	time, _ := strconv.ParseInt(trx.Timestamp, 10, 64)
+	block, _ := strconv.ParseUint(trx.BlockHeight, 10, 64)
+	sequence, _ := strconv.ParseUint(trx.Data.Source.Sequence, 10, 64)
+
+	tx = blockatlas.Tx{
+		ID: trx.Hash,
+		Coin: coin.THETA,
+		From: trx.Data.Source.Address,
+		To: trx.Data.Holder.Address,
+		Fee: blockatlas.Amount(trx.Data.Fee.Tfuelwei),
+		Date:  time,
+		Block: block,
+		Sequence: sequence,
+	}
+
+	value := blockatlas.Amount(trx.Data.Source.Coins.Thetawei)
+	if value == "" {
+		value = "0"
+	}
+
+	switch trx.Type {
+	case DepositStakeTransaction:
+		tx.Meta = blockatlas.Delegate{
+			Validator: trx.Data.Holder.Address,
+			Value: value,
+		}
+	case WithdrawStakeTransaction:
+		tx.Meta = blockatlas.Undelegate{
+			Validator: trx.Data.Holder.Address,
+			Value: value,
+		}
+	default:
+		return tx, false
 	}
 
-	return tx, false
+	return tx, true
 }
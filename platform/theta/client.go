@@ -1,9 +1,12 @@
 package theta
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -15,13 +18,13 @@ type Client struct {
 	BaseURL    string
 }
 
-func (c *Client) FetchAddressTransactions(address string) (txs []Tx, err error) {
+func (c *Client) FetchAddressTransactions(ctx context.Context, address string) (txs []Tx, err error) {
 	var transfers AccountTxList
 
 	uri := fmt.Sprintf("%s/accounttx/%s?type=2&pageNumber=1&limitNumber=100&isEqualType=true",
 		c.BaseURL, url.PathEscape(address))
 
-	resp, err := c.HTTPClient.Get(uri)
+	resp, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Error("THETA: Failed HTTP get transactions")
 		return nil, err
@@ -41,4 +44,25 @@ func (c *Client) FetchAddressTransactions(address string) (txs []Tx, err error)
 	}
 
 	return transfers.Body, nil
-}
\ No newline at end of file
+}
+
+func (c *Client) FetchAccount(ctx context.Context, address string) (*Account, error) {
+	var account AccountResponse
+
+	uri := fmt.Sprintf("%s/account/%s", c.BaseURL, url.PathEscape(address))
+
+	resp, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("THETA: Failed HTTP get account")
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&account)
+	if err != nil {
+		logrus.WithError(err).Error("THETA: Error decode account response body")
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return &account.Body, nil
+}
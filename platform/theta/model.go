@@ -2,7 +2,9 @@ package theta
 
 // THETA transaction types https://github.com/thetatoken/theta-mainnet-integration-guide/blob/master/docs/api.md#getblock
 const (
-	SendTransaction = 2
+	SendTransaction          = 2
+	DepositStakeTransaction  = 8
+	WithdrawStakeTransaction = 9
 )
 
 // Response from Explorer
@@ -22,6 +24,15 @@ type Data struct {
 	Fee     Fee       `json:"fee"`
 	Inputs  []Inputs  `json:"inputs"`
 	Outputs []Output `json:"outputs"`
+	// Stake transactions only
+	Source StakeParty `json:"source"`
+	Holder StakeParty `json:"holder"`
+}
+
+type StakeParty struct {
+	Address  string `json:"address"`
+	Coins    Fee    `json:"coins"`
+	Sequence string `json:"sequence"`
 }
 
 type Fee struct {
@@ -37,3 +48,15 @@ type Output struct {
 	Address string `json:"address"`
 	Coins   Fee    `json:"coins"`
 }
+
+// Account response from Explorer
+type AccountResponse struct {
+	Type string  `json:"type"`
+	Body Account `json:"body"`
+}
+
+type Account struct {
+	Address  string `json:"address"`
+	Balance  Fee    `json:"balance"`
+	Sequence string `json:"sequence"`
+}
@@ -1,10 +1,15 @@
 package tron
 
 import (
+	"context"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-	"net/http"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
+	"github.com/trustwallet/blockatlas/util"
+	"sync"
 )
 
 type Platform struct {
@@ -12,9 +17,9 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("tron.api")
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
 	p.client.Token = viper.GetString("tron.token")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -22,8 +27,28 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[coin.TRX]
 }
 
+// ValidateAddress returns the Base58 encoding of an address.
+// Hex addresses (starting with "41") are accepted as well.
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	if len(address) == 42 {
+		b58, err := HexToAddress(address)
+		if err != nil {
+			return "", blockatlas.ErrInvalidAddr
+		}
+		address = b58
+	}
+	if _, err := AddressToHex(address); err != nil {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return address, nil
+}
+
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	srcTxs, err := p.client.GetTxsOfAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	srcTxs, err := p.client.GetTxsOfAddress(ctx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -31,52 +56,242 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	var txs []blockatlas.Tx
 	for _, srcTx := range srcTxs {
 		tx, ok := Normalize(&srcTx)
-		if ok {
-			txs = append(txs, tx)
+		if !ok {
+			metrics.Dropped(p.Coin().Handle)
+			continue
 		}
+		txs = append(txs, tx)
 	}
 
 	return txs, nil
 }
 
-func (p *Platform) GetTokenTxsByAddress(address string, _ string) (blockatlas.TxPage, error) {
+func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
+	return p.GetTokenTxsByAddressContext(context.Background(), address, token)
+}
+
+func (p *Platform) GetTokenTxsByAddressContext(ctx context.Context, address string, _ string) (blockatlas.TxPage, error) {
 	// TODO: Filter by tokens
-	return p.GetTxsByAddress(address)
+	return p.GetTxsByAddressContext(ctx, address)
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if account == nil {
+		return "0", nil
+	}
+	return blockatlas.AmountFromUint64(account.Balance), nil
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+// assetInfoConns is the number of TRC10 token infos looked up at the same time
+const assetInfoConns = 8
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, nil
+	}
+
+	// TRC10 tokens, looked up concurrently
+	infos := make([]*AssetInfo, len(account.AssetsV2))
+	sem := util.NewSemaphore(assetInfoConns)
+	var wg sync.WaitGroup
+	wg.Add(len(account.AssetsV2))
+	for i := range account.AssetsV2 {
+		go func(i int) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			key := account.AssetsV2[i].Key
+			info, err := p.client.GetAssetInfo(ctx, key)
+			if err != nil {
+				logrus.WithError(err).WithField("platform", "tron").
+					Warnf("Failed to get info of TRC10 token %s", key)
+				return
+			}
+			infos[i] = info
+		}(i)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tokens []blockatlas.Token
+	for i, info := range infos {
+		if info != nil {
+			tokens = append(tokens, NormalizeAsset(&account.AssetsV2[i], info))
+		}
+	}
+
+	if len(account.TRC20) == 0 {
+		return tokens, nil
+	}
+
+	// TRC20 tokens
+	transfers, err := p.client.GetTRC20Transfers(ctx, address, 200)
+	if err != nil {
+		return nil, err
+	}
+	tokenInfos := make(map[string]*TokenInfo)
+	for i := range transfers {
+		info := &transfers[i].TokenInfo
+		tokenInfos[info.Address] = info
+	}
+	for _, holding := range account.TRC20 {
+		for contract, balance := range holding {
+			info, ok := tokenInfos[contract]
+			if !ok {
+				// Metadata is only known from recent transfers,
+				// the balance can't be shown without decimals
+				logrus.WithField("platform", "tron").
+					Warnf("No info of TRC20 token %s, leaving it out", contract)
+				continue
+			}
+			tokens = append(tokens, NormalizeTRC20(info, balance))
+		}
+	}
+
+	return tokens, nil
+}
+
+// NormalizeAsset converts a TRC10 token balance into the generic model
+func NormalizeAsset(asset *AssetV2, info *AssetInfo) blockatlas.Token {
+	return blockatlas.Token{
+		Name:     info.Name,
+		Symbol:   info.Abbr,
+		TokenID:  asset.Key,
+		Decimals: info.Precision,
+		Balance:  asset.Value,
+	}
+}
+
+// NormalizeTRC20 converts a TRC20 token balance into the generic model
+func NormalizeTRC20(info *TokenInfo, balance blockatlas.Amount) blockatlas.Token {
+	return blockatlas.Token{
+		Name:     info.Name,
+		Symbol:   info.Symbol,
+		TokenID:  info.Address,
+		Decimals: info.Decimals,
+		Balance:  balance,
+	}
 }
 
 /// Normalize converts a Tron transaction into the generic model
 func Normalize(srcTx *Tx) (tx blockatlas.Tx, ok bool) {
-	if len(srcTx.Data.Contracts) < 1 {
+	var ops []blockatlas.Op
+	for _, contract := range srcTx.Data.Contracts {
+		contractOps, err := normalizeContract(&contract)
+		if err != nil {
+			return tx, false
+		}
+		ops = append(ops, contractOps...)
+	}
+	if len(ops) == 0 {
 		return tx, false
 	}
 
-	// TODO Support multiple transfers in a single transaction
-	contract := &srcTx.Data.Contracts[0]
-	switch contract.Parameter.(type) {
-	case TransferContract:
-		transfer := contract.Parameter.(TransferContract)
+	tx = blockatlas.Tx{
+		ID:   srcTx.ID,
+		Coin: coin.TRX,
+		Date: srcTx.Data.Timestamp / 1000,
+		Fee:  "0",
+	}
+	tx.SetOps(ops)
+	return tx, true
+}
 
-		from, err := HexToAddress(transfer.Value.OwnerAddress)
+// normalizeContract converts a contract call into operations.
+// Unsupported contracts return no operations.
+func normalizeContract(contract *Contract) ([]blockatlas.Op, error) {
+	switch param := contract.Parameter.(type) {
+	case TransferContract:
+		from, err := HexToAddress(param.Value.OwnerAddress)
 		if err != nil {
-			return tx, false
+			return nil, err
 		}
-		to, err := HexToAddress(transfer.Value.ToAddress)
+		to, err := HexToAddress(param.Value.ToAddress)
 		if err != nil {
-			return tx, false
+			return nil, err
 		}
-
-		return blockatlas.Tx{
-			ID:   srcTx.ID,
-			Coin: coin.TRX,
-			Date: srcTx.Data.Timestamp / 1000,
+		return []blockatlas.Op{{
 			From: from,
 			To:   to,
-			Fee:  "0",
 			Meta: blockatlas.Transfer{
-				Value: transfer.Value.Amount,
+				Value: param.Value.Amount,
+			},
+		}}, nil
+	case FreezeBalanceContract:
+		from, err := HexToAddress(param.Value.OwnerAddress)
+		if err != nil {
+			return nil, err
+		}
+		return []blockatlas.Op{{
+			From: from,
+			Meta: blockatlas.Delegate{
+				Value: param.Value.FrozenBalance,
+			},
+		}}, nil
+	case UnfreezeBalanceContract:
+		from, err := HexToAddress(param.Value.OwnerAddress)
+		if err != nil {
+			return nil, err
+		}
+		// The unfrozen amount is not part of the contract
+		return []blockatlas.Op{{
+			From: from,
+			Meta: blockatlas.Undelegate{
+				Value: "0",
 			},
-		}, true
+		}}, nil
+	case VoteWitnessContract:
+		from, err := HexToAddress(param.Value.OwnerAddress)
+		if err != nil {
+			return nil, err
+		}
+		var ops []blockatlas.Op
+		for _, vote := range param.Value.Votes {
+			candidate, err := HexToAddress(vote.VoteAddress)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, blockatlas.Op{
+				From: from,
+				To:   candidate,
+				Meta: blockatlas.Vote{
+					Candidate: candidate,
+					Value:     blockatlas.AmountFromUint64(vote.VoteCount),
+				},
+			})
+		}
+		return ops, nil
+	case WithdrawBalanceContract:
+		from, err := HexToAddress(param.Value.OwnerAddress)
+		if err != nil {
+			return nil, err
+		}
+		// The claimed amount is not part of the contract
+		return []blockatlas.Op{{
+			To: from,
+			Meta: blockatlas.ClaimRewards{
+				Value: "0",
+			},
+		}}, nil
 	default:
-		return tx, false
+		return nil, nil
 	}
 }
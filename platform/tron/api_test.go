@@ -2,9 +2,14 @@ package tron
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -58,6 +63,49 @@ var transferDst = blockatlas.Tx{
 	},
 }
 
+const voteSrc = `
+{
+	"raw_data": {
+		"contract": [
+			{
+				"parameter": {
+					"type_url": "type.googleapis.com/protocol.VoteWitnessContract",
+					"value": {
+						"owner_address": "4182dd6b9966724ae2fdc79b416c7588da67ff1b35",
+						"votes": [
+							{
+								"vote_address": "410583a68a3bcd86c25ab1bee482bac04a216b0261",
+								"vote_count": 100
+							}
+						]
+					}
+				},
+				"type": "VoteWitnessContract"
+			}
+		],
+		"expiration": 1551357978000,
+		"ref_block_bytes": "1c17",
+		"ref_block_hash": "b25737f0375c676b",
+		"timestamp": 1551357920889
+	},
+	"txID": "a8cf4a2d0c8adc4b4c8aa3f1f6be7f2bb1c0e4fd7fa1f4d4e0b7c09f7e2c6a10"
+}
+`
+
+var voteDst = blockatlas.Tx{
+	ID: "a8cf4a2d0c8adc4b4c8aa3f1f6be7f2bb1c0e4fd7fa1f4d4e0b7c09f7e2c6a10",
+	Coin: coin.TRX,
+	From: "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+	To: "TAUN6FwrnwwmaEqYcckffC7wYmbaS6cBiX",
+	Fee: "0",
+	Date: 1551357920,
+	Status: blockatlas.StatusCompleted,
+	Meta: blockatlas.Vote{
+		Candidate: "TAUN6FwrnwwmaEqYcckffC7wYmbaS6cBiX",
+		Value: "100",
+	},
+}
+
 type test struct {
 	name string
 	apiResponse string
@@ -70,6 +118,11 @@ func TestNormalize(t *testing.T) {
 		apiResponse: transferSrc,
 		expected: &transferDst,
 	})
+	testNormalize(t, &test{
+		name: "vote",
+		apiResponse: voteSrc,
+		expected: &voteDst,
+	})
 }
 
 func testNormalize(t *testing.T, _test *test) {
@@ -90,7 +143,7 @@ func testNormalize(t *testing.T, _test *test) {
 		t.Fatal(err)
 	}
 
-	dstJSON, err := json.Marshal(&transferDst)
+	dstJSON, err := json.Marshal(_test.expected)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -101,3 +154,43 @@ func testNormalize(t *testing.T, _test *test) {
 		t.Errorf("%s: tx don't equal", _test.name)
 	}
 }
+
+func TestPlatform_GetTokenListByAddress(t *testing.T) {
+	const address = "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/" + address:
+			fmt.Fprint(w, `{"success": true, "data": [{
+				"assetV2": [{"key": "1002000", "value": 100}, {"key": "1000001", "value": 200}],
+				"trc20": [{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t": "300"}, {"TUnknown": "400"}]
+			}]}`)
+		case "/assets/1002000":
+			fmt.Fprint(w, `{"success": true, "data": [{"id": 1002000, "name": "BitTorrent", "abbr": "BTT", "precision": 6}]}`)
+		case "/assets/1000001":
+			fmt.Fprint(w, `{"success": true, "data": [{"id": 1000001, "name": "SEED", "abbr": "SEED", "precision": 0}]}`)
+		case "/accounts/" + address + "/transactions/trc20":
+			fmt.Fprint(w, `{"success": true, "data": [{"token_info": {
+				"address": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "name": "Tether USD", "symbol": "USDT", "decimals": 6
+			}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), BaseURL: server.URL}}
+	tokens, err := p.GetTokenListByAddressContext(context.Background(), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := blockatlas.TokenPage{
+		{Name: "BitTorrent", Symbol: "BTT", TokenID: "1002000", Decimals: 6, Balance: "100"},
+		{Name: "SEED", Symbol: "SEED", TokenID: "1000001", Decimals: 0, Balance: "200"},
+		// TRC20 tokens without known decimals are left out
+		{Name: "Tether USD", Symbol: "USDT", TokenID: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Decimals: 6, Balance: "300"},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("expected %v, got %v", expected, tokens)
+	}
+}
@@ -1,10 +1,12 @@
 package tron
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -17,7 +19,7 @@ type Client struct {
 	Token      string
 }
 
-func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string) ([]Tx, error) {
 	uri := fmt.Sprintf("%s/accounts/%s/transactions?%s",
 		c.BaseURL,
 		url.PathEscape(address),
@@ -26,7 +28,7 @@ func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
 			"only_confirmed": {"true"},
 			"limit": {strconv.Itoa(blockatlas.TxPerPage)},
 		}.Encode())
-	httpRes, err := c.HTTPClient.Get(uri)
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
 	if err != nil {
 		logrus.WithError(err).Error("Tron: Failed to get transactions")
 		return nil, blockatlas.ErrSourceConn
@@ -37,7 +39,7 @@ func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
 	err = json.NewDecoder(httpRes.Body).Decode(&res)
 	if err != nil {
 		logrus.WithError(err).Error("Tron: Failed to decode API response")
-		return nil, blockatlas.ErrSourceConn
+		return nil, blockatlas.ErrDecode(err)
 	}
 
 	if !res.Success {
@@ -47,3 +49,102 @@ func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
 
 	return res.Txs, nil
 }
+
+func (c *Client) GetAccount(ctx context.Context, address string) (*Account, error) {
+	uri := fmt.Sprintf("%s/accounts/%s?%s",
+		c.BaseURL,
+		url.PathEscape(address),
+		url.Values{
+			"experimental": {c.Token},
+		}.Encode())
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to get account")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	var res AccountsPage
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to decode API response")
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	if !res.Success {
+		logrus.WithField("error", res.Error).Error("Tron: API returned error")
+		return nil, blockatlas.ErrSourceConn
+	}
+
+	// Accounts without any activity are not returned
+	if len(res.Accounts) == 0 {
+		return nil, nil
+	}
+
+	return &res.Accounts[0], nil
+}
+
+func (c *Client) GetAssetInfo(ctx context.Context, id string) (*AssetInfo, error) {
+	uri := fmt.Sprintf("%s/assets/%s?%s",
+		c.BaseURL,
+		url.PathEscape(id),
+		url.Values{
+			"experimental": {c.Token},
+		}.Encode())
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to get asset")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	var res AssetsPage
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to decode API response")
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	if !res.Success {
+		logrus.WithField("error", res.Error).Error("Tron: API returned error")
+		return nil, blockatlas.ErrSourceConn
+	}
+
+	if len(res.Assets) == 0 {
+		return nil, blockatlas.ErrNotFound
+	}
+
+	return &res.Assets[0], nil
+}
+
+// GetTRC20Transfers returns the latest TRC20 transfers of an address.
+// TRC20 metadata is only available as part of transfers.
+func (c *Client) GetTRC20Transfers(ctx context.Context, address string, limit int) ([]TRC20Transfer, error) {
+	uri := fmt.Sprintf("%s/accounts/%s/transactions/trc20?%s",
+		c.BaseURL,
+		url.PathEscape(address),
+		url.Values{
+			"experimental": {c.Token},
+			"limit":        {strconv.Itoa(limit)},
+		}.Encode())
+	httpRes, err := upstream.Get(ctx, c.HTTPClient, uri)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to get TRC20 transfers")
+		return nil, blockatlas.ErrSourceConn
+	}
+	defer httpRes.Body.Close()
+
+	var res TRC20TransfersPage
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+	if err != nil {
+		logrus.WithError(err).Error("Tron: Failed to decode API response")
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	if !res.Success {
+		logrus.WithField("error", res.Error).Error("Tron: API returned error")
+		return nil, blockatlas.ErrSourceConn
+	}
+
+	return res.Transfers, nil
+}
@@ -11,6 +11,62 @@ type Page struct {
 	Txs     []Tx   `json:"data"`
 }
 
+type AccountsPage struct {
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	Accounts []Account `json:"data"`
+}
+
+type Account struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+	// TRC10 balances
+	AssetsV2 []AssetV2 `json:"assetV2"`
+	// TRC20 balances by contract address
+	TRC20 []map[string]blockatlas.Amount `json:"trc20"`
+}
+
+type AssetV2 struct {
+	Key   string            `json:"key"`
+	Value blockatlas.Amount `json:"value"`
+}
+
+type AssetsPage struct {
+	Success bool        `json:"success"`
+	Error   string      `json:"error,omitempty"`
+	Assets  []AssetInfo `json:"data"`
+}
+
+// AssetInfo describes a TRC10 token
+type AssetInfo struct {
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Abbr      string `json:"abbr"`
+	Precision uint   `json:"precision"`
+}
+
+type TRC20TransfersPage struct {
+	Success   bool            `json:"success"`
+	Error     string          `json:"error,omitempty"`
+	Transfers []TRC20Transfer `json:"data"`
+}
+
+type TRC20Transfer struct {
+	TxID      string            `json:"transaction_id"`
+	TokenInfo TokenInfo         `json:"token_info"`
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Value     blockatlas.Amount `json:"value"`
+}
+
+// TokenInfo describes a TRC20 token
+type TokenInfo struct {
+	Address  string `json:"address"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint   `json:"decimals"`
+}
+
 type Tx struct {
 	ID   string `json:"txID"`
 	Data TxData `json:"raw_data"`
@@ -38,6 +94,51 @@ type TransferValue struct {
 	ToAddress    string        `json:"to_address"`
 }
 
+// FreezeBalanceContract stakes TRX for bandwidth or energy
+type FreezeBalanceContract struct {
+	Value FreezeBalanceValue `json:"value"`
+}
+
+type FreezeBalanceValue struct {
+	OwnerAddress    string            `json:"owner_address"`
+	FrozenBalance   blockatlas.Amount `json:"frozen_balance"`
+	ReceiverAddress string            `json:"receiver_address"`
+}
+
+// UnfreezeBalanceContract unstakes all frozen TRX
+type UnfreezeBalanceContract struct {
+	Value UnfreezeBalanceValue `json:"value"`
+}
+
+type UnfreezeBalanceValue struct {
+	OwnerAddress    string `json:"owner_address"`
+	ReceiverAddress string `json:"receiver_address"`
+}
+
+// VoteWitnessContract casts votes for super representatives
+type VoteWitnessContract struct {
+	Value VoteWitnessValue `json:"value"`
+}
+
+type VoteWitnessValue struct {
+	OwnerAddress string `json:"owner_address"`
+	Votes        []Vote `json:"votes"`
+}
+
+type Vote struct {
+	VoteAddress string `json:"vote_address"`
+	VoteCount   uint64 `json:"vote_count"`
+}
+
+// WithdrawBalanceContract claims the rewards of a super representative
+type WithdrawBalanceContract struct {
+	Value WithdrawBalanceValue `json:"value"`
+}
+
+type WithdrawBalanceValue struct {
+	OwnerAddress string `json:"owner_address"`
+}
+
 func (c *Contract) UnmarshalJSON(buf []byte) error {
 	var contractInternal struct {
 		Type      string          `json:"type"`
@@ -52,6 +153,22 @@ func (c *Contract) UnmarshalJSON(buf []byte) error {
 		var transfer TransferContract
 		err = json.Unmarshal(contractInternal.Parameter, &transfer)
 		c.Parameter = transfer
+	case "FreezeBalanceContract":
+		var freeze FreezeBalanceContract
+		err = json.Unmarshal(contractInternal.Parameter, &freeze)
+		c.Parameter = freeze
+	case "UnfreezeBalanceContract":
+		var unfreeze UnfreezeBalanceContract
+		err = json.Unmarshal(contractInternal.Parameter, &unfreeze)
+		c.Parameter = unfreeze
+	case "VoteWitnessContract":
+		var vote VoteWitnessContract
+		err = json.Unmarshal(contractInternal.Parameter, &vote)
+		c.Parameter = vote
+	case "WithdrawBalanceContract":
+		var withdraw WithdrawBalanceContract
+		err = json.Unmarshal(contractInternal.Parameter, &withdraw)
+		c.Parameter = withdraw
 	}
 	return err
 }
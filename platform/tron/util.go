@@ -3,6 +3,7 @@ package tron
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"github.com/mr-tron/base58"
 )
 
@@ -20,3 +21,24 @@ func HexToAddress(hexAddr string) (b58 string, err error) {
 	b58 = base58.EncodeAlphabet(bytes, base58.BTCAlphabet)
 	return
 }
+
+// AddressToHex converts a Base58 Tron address into its hex representation.
+// Fails if the checksum or the address prefix (0x41) is invalid.
+func AddressToHex(b58 string) (hexAddr string, err error) {
+	bytes, err := base58.DecodeAlphabet(b58, base58.BTCAlphabet)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes) != 25 || bytes[0] != 0x41 {
+		return "", fmt.Errorf("not a Tron address: %s", b58)
+	}
+	payload := bytes[:21]
+	checksum := sha256.Sum256(payload)
+	checksum = sha256.Sum256(checksum[:])
+	for i := 0; i < 4; i++ {
+		if bytes[21+i] != checksum[i] {
+			return "", fmt.Errorf("invalid checksum: %s", b58)
+		}
+	}
+	return hex.EncodeToString(payload), nil
+}
@@ -13,3 +13,25 @@ func TestHexToAddress(t *testing.T) {
 		t.Fatalf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestAddressToHex(t *testing.T) {
+	in := "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+	expected := "4182dd6b9966724ae2fdc79b416c7588da67ff1b35"
+	got, err := AddressToHex(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected != got {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+
+	for _, invalid := range []string{
+		"TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY8",
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		"TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY0",
+	} {
+		if _, err := AddressToHex(invalid); err == nil {
+			t.Errorf("%s: expected error", invalid)
+		}
+	}
+}
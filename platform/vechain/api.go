@@ -1,15 +1,15 @@
 package vechain
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/metrics"
+	"github.com/trustwallet/blockatlas/upstream"
 	"github.com/trustwallet/blockatlas/util"
-	"net/http"
 	"strings"
 	"sync"
-
-	"github.com/spf13/viper"
 )
 
 type Platform struct {
@@ -17,8 +17,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.URL = viper.GetString("vechain.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.URL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -26,22 +26,39 @@ func (p *Platform) Coin() coin.Coin {
 	return coin.Coins[coin.VET]
 }
 
+// ValidateAddress returns the EIP-55 checksum encoding of an address
+func (p *Platform) ValidateAddress(address string) (string, error) {
+	checksummed, err := util.EIP55Checksum(address)
+	if err != nil {
+		return "", blockatlas.ErrInvalidAddr
+	}
+	return checksummed, nil
+}
+
 const VeThorContract = "0x0000000000000000000000000000456e65726779"
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	return p.getTokenTxsByAddress(address)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	return p.getTokenTxsByAddress(ctx, address)
 }
 
 func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
+	return p.GetTokenTxsByAddressContext(context.Background(), address, token)
+}
+
+func (p *Platform) GetTokenTxsByAddressContext(ctx context.Context, address string, token string) (blockatlas.TxPage, error) {
 	if strings.ToLower(token) == VeThorContract {
-		return p.getThorTxsByAddress(address)
+		return p.getThorTxsByAddress(ctx, address)
 	} else {
 		return nil, nil
 	}
 }
 
-func (p *Platform) getThorTxsByAddress(address string) ([]blockatlas.Tx, error) {
-	sourceTxs, _ := p.client.GetTokenTransfers(address)
+func (p *Platform) getThorTxsByAddress(ctx context.Context, address string) ([]blockatlas.Tx, error) {
+	sourceTxs, _ := p.client.GetTokenTransfers(ctx, address)
 
 	var txs []blockatlas.Tx
 	for _, t := range sourceTxs.TokenTransfers {
@@ -49,16 +66,19 @@ func (p *Platform) getThorTxsByAddress(address string) ([]blockatlas.Tx, error)
 			continue
 		}
 
-		if tx, ok := NormalizeTokenTransfer(&t); ok {
-			txs = append(txs, tx)
+		tx, ok := NormalizeTokenTransfer(&t)
+		if !ok {
+			metrics.Dropped(p.Coin().Handle)
+			continue
 		}
+		txs = append(txs, tx)
 	}
 
 	return txs, nil
 }
 
-func (p *Platform) getTokenTxsByAddress(address string) ([]blockatlas.Tx, error) {
-	sourceTxs, _ := p.client.GetTransactions(address)
+func (p *Platform) getTokenTxsByAddress(ctx context.Context, address string) ([]blockatlas.Tx, error) {
+	sourceTxs, _ := p.client.GetTransactions(ctx, address)
 
 	receiptsChan := make(chan *TransferReceipt, len(sourceTxs.Transactions))
 
@@ -66,30 +86,40 @@ func (p *Platform) getTokenTxsByAddress(address string) ([]blockatlas.Tx, error)
 	var wg sync.WaitGroup
 	wg.Add(len(sourceTxs.Transactions))
 	for _, t := range sourceTxs.Transactions {
-		go func() {
+		go func(t Tx) {
 			defer wg.Done()
 			sem.Acquire()
 			defer sem.Release()
-			receipt, err := p.client.GetTransactionReceipt(t.ID)
+			// Skip the remaining lookups once the caller is gone
+			if ctx.Err() != nil {
+				return
+			}
+			receipt, err := p.client.GetTransactionReceipt(ctx, t.ID)
 			if err != nil {
 				logrus.WithError(err).WithField("platform", "vechain").
 					Warnf("Failed to get tx receipt for %s", t.ID)
+				return
 			}
 			receiptsChan <- receipt
-		}()
+		}(t)
 	}
 
 	wg.Wait()
 	close(receiptsChan)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	var txs []blockatlas.Tx
 	for receipt := range receiptsChan {
 		for _, clause := range receipt.Clauses {
-			if receipt.Origin != address && clause.To != address {
+			// VeForge returns lowercase addresses, address is checksummed
+			if !strings.EqualFold(receipt.Origin, address) && !strings.EqualFold(clause.To, address) {
 				continue
 			}
 			tx, ok := NormalizeTransfer(receipt, &clause)
 			if !ok {
+				metrics.Dropped(p.Coin().Handle)
 				continue
 			}
 			txs = append(txs, tx)
@@ -99,17 +129,84 @@ func (p *Platform) getTokenTxsByAddress(address string) ([]blockatlas.Tx, error)
 	return txs, nil
 }
 
+func (p *Platform) GetTx(id string) (*blockatlas.Tx, error) {
+	return p.GetTxContext(context.Background(), id)
+}
+
+// GetTxContext returns a transaction with an operation per clause
+func (p *Platform) GetTxContext(ctx context.Context, id string) (*blockatlas.Tx, error) {
+	receipt, err := p.client.GetTransactionReceipt(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.ID == "" {
+		return nil, blockatlas.ErrNotFound
+	}
+
+	var tx blockatlas.Tx
+	var ops []blockatlas.Op
+	for i := range receipt.Clauses {
+		clauseTx, ok := NormalizeTransfer(receipt, &receipt.Clauses[i])
+		if !ok {
+			metrics.Dropped(p.Coin().Handle)
+			continue
+		}
+		if len(ops) == 0 {
+			tx = clauseTx
+		}
+		ops = append(ops, blockatlas.Op{From: clauseTx.From, To: clauseTx.To, Meta: clauseTx.Meta})
+	}
+	if len(ops) == 0 {
+		return nil, blockatlas.ErrNotFound
+	}
+	tx.SetOps(ops)
+	return &tx, nil
+}
+
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return blockatlas.ParseHexAmount(account.Balance)
+}
+
+func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage, error) {
+	return p.GetTokenListByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTokenListByAddressContext(ctx context.Context, address string) (blockatlas.TokenPage, error) {
+	account, err := p.client.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := blockatlas.ParseHexAmount(account.Energy)
+	if err != nil {
+		return nil, err
+	}
+	return []blockatlas.Token{{
+		Name:     "VeThor Token",
+		Symbol:   "VTHO",
+		TokenID:  VeThorContract,
+		Decimals: 18,
+		Balance:  energy,
+	}}, nil
+}
+
 func NormalizeTransfer(receipt *TransferReceipt, clause *Clause) (tx blockatlas.Tx, ok bool) {
-	feeBase10, err := util.HexToDecimal(receipt.Receipt.Paid)
+	fee, err := blockatlas.ParseHexAmount(receipt.Receipt.Paid)
 	if err != nil {
 		return tx, false
 	}
-	valueBase10, err := util.HexToDecimal(clause.Value)
+	value, err := blockatlas.ParseHexAmount(clause.Value)
 	if err != nil {
 		return tx, false
 	}
 
-	fee := blockatlas.Amount(feeBase10)
 	time := receipt.Timestamp
 	block := receipt.Block
 
@@ -124,17 +221,16 @@ func NormalizeTransfer(receipt *TransferReceipt, clause *Clause) (tx blockatlas.
 		Block:    block,
 		Sequence: block,
 		Meta: blockatlas.Transfer{
-			Value: blockatlas.Amount(valueBase10),
+			Value: value,
 		},
 	}, true
 }
 
 func NormalizeTokenTransfer(t *TokenTransfer) (tx blockatlas.Tx, ok bool) {
-	valueBase10, err := util.HexToDecimal(t.Amount)
+	value, err := blockatlas.ParseHexAmount(t.Amount)
 	if err != nil {
 		return tx, false
 	}
-	value := blockatlas.Amount(valueBase10)
 	from := t.Origin
 	to := t.Receiver
 	block := t.Block
@@ -2,9 +2,14 @@ package vechain
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -162,3 +167,68 @@ func TestNormalizeTokenTransfer(t *testing.T) {
 		}
 	}
 }
+
+func TestPlatform_GetTxsByAddress_Checksummed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/transactions":
+			fmt.Fprint(w, `{"transactions": [{"id": "0x2b8776bd4679fa2afa28b55d66d4f6c7c77522fc878ce294d25e32475b704517"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/transactions/"):
+			fmt.Fprintf(w, `{
+				"block": 2620166,
+				"id": "0x2b8776bd4679fa2afa28b55d66d4f6c7c77522fc878ce294d25e32475b704517",
+				"origin": "0xb853d6a965fbc047aaa9f04d774d53861d7ed653",
+				"timestamp": 1556569300,
+				"receipt": {"paid": "0x1236efcbcbb340000"},
+				"clauses": [%s]
+			}`, transferClause)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), URL: server.URL}}
+	address, err := p.ValidateAddress("0xb853d6a965fbc047aaa9f04d774d53861d7ed653")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address == strings.ToLower(address) {
+		t.Fatalf("expected checksummed address, got %s", address)
+	}
+
+	txs, err := p.GetTxsByAddressContext(context.Background(), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].ID != expectedTransferTrx.ID {
+		t.Errorf("expected transaction of checksummed address, got %v", txs)
+	}
+}
+
+func TestPlatform_GetTx_Clauses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"block": 2620166,
+			"id": "0x2b8776bd4679fa2afa28b55d66d4f6c7c77522fc878ce294d25e32475b704517",
+			"origin": "0xb853d6a965fbc047aaa9f04d774d53861d7ed653",
+			"timestamp": 1556569300,
+			"receipt": {"paid": "0x1236efcbcbb340000"},
+			"clauses": [%s, {"to": "0x9f3742c2c2fe66c7fca08d77d2262c22e3d56ac8", "value": "0x1"}]
+		}`, transferClause)
+	}))
+	defer server.Close()
+
+	p := &Platform{client: Client{HTTPClient: server.Client(), URL: server.URL}}
+	tx, err := p.GetTxContext(context.Background(), expectedTransferTrx.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.To != expectedTransferTrx.To || len(tx.Ops) != 2 {
+		t.Fatalf("expected a transaction with 2 clauses, got %+v", tx)
+	}
+	if op := tx.Ops[1]; op.To != "0x9f3742c2c2fe66c7fca08d77d2262c22e3d56ac8" ||
+		op.Meta != (blockatlas.Transfer{Value: "1"}) {
+		t.Errorf("unexpected second clause %+v", op)
+	}
+}
@@ -1,9 +1,12 @@
 package vechain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/upstream"
 	"io/ioutil"
 	"net/http"
 )
@@ -13,11 +16,11 @@ type Client struct {
 	URL        string
 }
 
-func (c *Client) GetTransactions(address string) (TransferTx, error) {
+func (c *Client) GetTransactions(ctx context.Context, address string) (TransferTx, error) {
 	var transfers TransferTx
 
 	url := fmt.Sprintf("%s/transactions?address=%s&count=25&offset=0", c.URL, address)
-	resp, err := c.HTTPClient.Get(url)
+	resp, err := upstream.Get(ctx, c.HTTPClient, url)
 	if err != nil {
 		logrus.WithError(err).Error("VeChain: Failed HTTP get transactions")
 		return transfers, err
@@ -39,11 +42,11 @@ func (c *Client) GetTransactions(address string) (TransferTx, error) {
 	return transfers, nil
 }
 
-func (c *Client) GetTokenTransfers(address string) (TokenTransferTxs, error) {
+func (c *Client) GetTokenTransfers(ctx context.Context, address string) (TokenTransferTxs, error) {
 	var transfers TokenTransferTxs
 
 	url := fmt.Sprintf("%s/tokenTransfers?address=%s&count=25&offset=0", c.URL, address)
-	resp, err := c.HTTPClient.Get(url)
+	resp, err := upstream.Get(ctx, c.HTTPClient, url)
 	if err != nil {
 		logrus.WithError(err).Error("VeChain: Failed HTTP get token trasnfer transactions")
 		return transfers, err
@@ -65,9 +68,27 @@ func (c *Client) GetTokenTransfers(address string) (TokenTransferTxs, error) {
 	return transfers, nil
 }
 
-func (c *Client) GetTransactionReceipt(id string) (*TransferReceipt, error) {
+func (c *Client) GetAccount(ctx context.Context, address string) (*Account, error) {
+	url := fmt.Sprintf("%s/accounts/%s", c.URL, address)
+	resp, err := upstream.Get(ctx, c.HTTPClient, url)
+	if err != nil {
+		logrus.WithError(err).Error("VeChain: Failed HTTP get account")
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var account Account
+	err = json.NewDecoder(resp.Body).Decode(&account)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return &account, nil
+}
+
+func (c *Client) GetTransactionReceipt(ctx context.Context, id string) (*TransferReceipt, error) {
 	url := fmt.Sprintf("%s/transactions/%s", c.URL, id)
-	resp, err := c.HTTPClient.Get(url)
+	resp, err := upstream.Get(ctx, c.HTTPClient, url)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +97,7 @@ func (c *Client) GetTransactionReceipt(id string) (*TransferReceipt, error) {
 	var receipt TransferReceipt
 	err = json.NewDecoder(resp.Body).Decode(&receipt)
 	if err != nil {
-		return nil, err
+		return nil, blockatlas.ErrDecode(err)
 	}
 
 	return &receipt, nil
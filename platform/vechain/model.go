@@ -1,5 +1,11 @@
 package vechain
 
+type Account struct {
+	Balance string `json:"balance"`
+	Energy  string `json:"energy"`
+	HasCode bool   `json:"hasCode"`
+}
+
 type TransferTx struct {
 	Transactions []Tx   `json:"transactions"`
 }
@@ -1,12 +1,11 @@
 package waves
 
 import (
-	"net/http"
-	"strconv"
+	"context"
 
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/upstream"
 
-	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas"
 )
 
@@ -15,8 +14,8 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.URL = viper.GetString("waves.api")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.URL = upstream.URL(p.Coin().Handle)
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -25,7 +24,11 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
-	addressTxs, err := p.client.GetTxs(address, 25)
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
+	addressTxs, err := p.client.GetTxs(ctx, address, 25)
 	if err != nil {
 		return nil, err
 	}
@@ -38,19 +41,31 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	return txs, nil
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	balance, err := p.client.GetBalance(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return blockatlas.AmountFromUint64(balance.Balance), nil
+}
+
 func NormalizeTx(srcTx *Transaction, coinIndex uint) blockatlas.Tx {
 	return blockatlas.Tx{
 		ID:     srcTx.Id,
 		Coin:   coinIndex,
 		From:   srcTx.Sender,
 		To:     srcTx.Recipient,
-		Fee:    blockatlas.Amount(strconv.Itoa(int(srcTx.Fee))),
+		Fee:    blockatlas.AmountFromUint64(srcTx.Fee),
 		Date:   int64(srcTx.Timestamp) / 1000,
 		Block:  srcTx.Block,
 		Memo:   srcTx.Attachment,
 		Status: blockatlas.StatusCompleted,
 		Meta:   blockatlas.Transfer{
-			Value: blockatlas.Amount(strconv.Itoa(int(srcTx.Amount))),
+			Value: blockatlas.AmountFromUint64(srcTx.Amount),
 		},
 	}
 }
@@ -1,8 +1,10 @@
 package waves
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/trustwallet/blockatlas"
 	"net/http"
 )
 
@@ -11,12 +13,12 @@ type Client struct {
 	URL    string
 }
 
-func (c *Client) GetTxs(address string, limit int) ([]Transaction, error) {
+func (c *Client) GetTxs(ctx context.Context, address string, limit int) ([]Transaction, error) {
 	uri := fmt.Sprintf("%s/transactions/address/%s/limit/%d",
 	c.URL,
 	address,
 	limit)
-	req, _ := http.NewRequest("GET", uri, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", uri, nil)
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -25,13 +27,13 @@ func (c *Client) GetTxs(address string, limit int) ([]Transaction, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("http %s", res.Status)
+		return nil, blockatlas.ErrStatus(res.StatusCode)
 	}
 
 	txsArrays := new([][]Transaction)
 	err = json.NewDecoder(res.Body).Decode(txsArrays)
 	if err != nil {
-		return nil, err
+		return nil, blockatlas.ErrDecode(err)
 	}
 	txsObj := *txsArrays
 	txs := txsObj[0]
@@ -45,4 +47,29 @@ func (c *Client) GetTxs(address string, limit int) ([]Transaction, error) {
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
+
+func (c *Client) GetBalance(ctx context.Context, address string) (*Balance, error) {
+	uri := fmt.Sprintf("%s/addresses/balance/%s",
+		c.URL,
+		address)
+	req, _ := http.NewRequestWithContext(ctx, "GET", uri, nil)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, blockatlas.ErrStatus(res.StatusCode)
+	}
+
+	balance := new(Balance)
+	err = json.NewDecoder(res.Body).Decode(balance)
+	if err != nil {
+		return nil, blockatlas.ErrDecode(err)
+	}
+
+	return balance, nil
+}
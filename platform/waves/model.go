@@ -1,5 +1,11 @@
 package waves
 
+type Balance struct {
+	Address       string `json:"address"`
+	Confirmations uint64 `json:"confirmations"`
+	Balance       uint64 `json:"balance"`
+}
+
 type Transaction struct {
 	Id         string `json:"id"`
 	Sender     string `json:"sender"`
@@ -1,10 +1,10 @@
 package zilliqa
 
 import (
+	"context"
 	"github.com/trustwallet/blockatlas"
 	"github.com/trustwallet/blockatlas/coin"
-
-	"net/http"
+	"github.com/trustwallet/blockatlas/upstream"
 
 	"github.com/spf13/viper"
 )
@@ -14,9 +14,9 @@ type Platform struct {
 }
 
 func (p *Platform) Init() error {
-	p.client.BaseURL = viper.GetString("zilliqa.api")
+	p.client.BaseURL = upstream.URL(p.Coin().Handle)
 	p.client.APIKey = viper.GetString("zilliqa.key")
-	p.client.HTTPClient = http.DefaultClient
+	p.client.HTTPClient = upstream.New(p.Coin().Handle)
 	return nil
 }
 
@@ -25,8 +25,12 @@ func (p *Platform) Coin() coin.Coin {
 }
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
+	return p.GetTxsByAddressContext(context.Background(), address)
+}
+
+func (p *Platform) GetTxsByAddressContext(ctx context.Context, address string) (blockatlas.TxPage, error) {
 	var normalized []blockatlas.Tx
-	txs, err := p.client.GetTxsOfAddress(address)
+	txs, err := p.client.GetTxsOfAddress(ctx, address)
 
 	if err != nil {
 		return nil, err
@@ -43,6 +47,21 @@ func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
 	return normalized, nil
 }
 
+func (p *Platform) GetBalance(address string) (blockatlas.Amount, error) {
+	return p.GetBalanceContext(context.Background(), address)
+}
+
+func (p *Platform) GetBalanceContext(ctx context.Context, address string) (blockatlas.Amount, error) {
+	addr, err := p.client.GetAddress(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	if addr.Balance == "" {
+		return "0", nil
+	}
+	return blockatlas.Amount(addr.Balance), nil
+}
+
 func Normalize(srcTx *Tx) (tx blockatlas.Tx) {
 	tx = blockatlas.Tx{
 		ID:       srcTx.Hash,
@@ -2,6 +2,7 @@ package zilliqa
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -16,9 +17,9 @@ type Client struct {
 	APIKey     string
 }
 
-func (c *Client) newRequest(method, path string) (*http.Request, error) {
+func (c *Client) newRequest(ctx context.Context, method, path string) (*http.Request, error) {
 	url := fmt.Sprintf("%s%s", c.BaseURL, path)
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +27,9 @@ func (c *Client) newRequest(method, path string) (*http.Request, error) {
 	return req, nil
 }
 
-func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
+func (c *Client) GetTxsOfAddress(ctx context.Context, address string) ([]Tx, error) {
 	path := fmt.Sprintf("/addresses/%s/txs", address)
-	req, _ := c.newRequest("GET", path)
+	req, _ := c.newRequest(ctx, "GET", path)
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
 		logrus.WithError(err).Error("Zilliqa: Failed to get transactions for ", address)
@@ -55,3 +56,33 @@ func (c *Client) GetTxsOfAddress(address string) ([]Tx, error) {
 
 	return txs, nil
 }
+
+func (c *Client) GetAddress(ctx context.Context, address string) (*Address, error) {
+	path := fmt.Sprintf("/addresses/%s", address)
+	req, _ := c.newRequest(ctx, "GET", path)
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		logrus.WithError(err).Error("Zilliqa: Failed to get address ", address)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		logrus.WithError(err).Error("Zilliqa: Error read response body")
+		return nil, err
+	}
+
+	if bytes.HasPrefix(body, []byte(`{"message":"Invalid API key specified"`)) {
+		return nil, fmt.Errorf("invalid Zilliqa API key")
+	}
+
+	addr := new(Address)
+	err = json.Unmarshal(body, addr)
+	if err != nil {
+		logrus.WithError(err).Error("Zilliqa: Error decode json address response")
+		return nil, err
+	}
+
+	return addr, nil
+}
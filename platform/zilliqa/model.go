@@ -1,5 +1,7 @@
 package zilliqa
 
+import "encoding/json"
+
 type Tx struct {
 	Hash           string `json:"hash"`
 	BlockHeight    uint64 `json:"blockHeight"`
@@ -12,3 +14,9 @@ type Tx struct {
 	Nonce          uint64 `json:"nonce"`
 	ReceiptSuccess bool   `json:"receiptSuccess"`
 }
+
+type Address struct {
+	Hash    string      `json:"hash"`
+	Balance json.Number `json:"balance"`
+	Nonce   uint64      `json:"nonce"`
+}
@@ -0,0 +1,56 @@
+package blockatlas
+
+// Balance is the balance of an address
+type Balance struct {
+	Balance  Amount `json:"balance"`
+	Decimals uint   `json:"decimals"`
+}
+
+// Portfolio is the merged activity of addresses across coins
+// along with the errors of failed lookups by coin and address
+type Portfolio struct {
+	Txs      TxPage
+	Balances map[uint]map[string]Balance
+	Errors   map[uint]map[string]error
+}
+
+// Add appends the transactions of a coin that are not part of the portfolio yet
+func (r *Portfolio) Add(txs TxPage) {
+	type key struct {
+		coin uint
+		id   string
+	}
+	known := make(map[key]bool, len(r.Txs))
+	for _, tx := range r.Txs {
+		known[key{tx.Coin, tx.ID}] = true
+	}
+	for _, tx := range txs {
+		k := key{tx.Coin, tx.ID}
+		if !known[k] {
+			known[k] = true
+			r.Txs = append(r.Txs, tx)
+		}
+	}
+}
+
+// AddBalance records the balance of an address
+func (r *Portfolio) AddBalance(coin uint, address string, balance Balance) {
+	if r.Balances == nil {
+		r.Balances = make(map[uint]map[string]Balance)
+	}
+	if r.Balances[coin] == nil {
+		r.Balances[coin] = make(map[string]Balance)
+	}
+	r.Balances[coin][address] = balance
+}
+
+// AddError records the failed lookup of an address
+func (r *Portfolio) AddError(coin uint, address string, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[uint]map[string]error)
+	}
+	if r.Errors[coin] == nil {
+		r.Errors[coin] = make(map[string]error)
+	}
+	r.Errors[coin][address] = err
+}
@@ -0,0 +1,13 @@
+package blockatlas
+
+// TokenPage is a list of tokens
+type TokenPage []Token
+
+// Token describes a token held by an address
+type Token struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	TokenID  string `json:"token_id"`
+	Decimals uint   `json:"decimals"`
+	Balance  Amount `json:"balance"`
+}
@@ -8,12 +8,24 @@ const (
 	TxCollectibleTransfer = "collectible_transfer"
 	TxTokenSwap           = "token_swap"
 	TxContractCall        = "contract_call"
+	TxDelegate            = "delegate"
+	TxUndelegate          = "undelegate"
+	TxClaimRewards        = "claim_rewards"
+	TxVote                = "vote"
 )
 
 // Types of transaction statuses
 const (
 	StatusCompleted = "completed"
 	StatusFailed    = "failed"
+	StatusPending   = "pending"
+)
+
+// Directions of transactions relative to an address
+const (
+	DirectionOutgoing = "outgoing"
+	DirectionIncoming = "incoming"
+	DirectionSelf     = "self"
 )
 
 // TxPerPage says how many transactions to return per page
@@ -22,6 +34,56 @@ const TxPerPage = 25
 // TxPage is a page of transactions
 type TxPage []Tx
 
+// TxCursorPage is a page of transactions
+// along with the cursor pointing to the next page
+type TxCursorPage struct {
+	Txs  TxPage
+	Next string
+}
+
+// TxBatchPage is the merged page of transactions of multiple
+// addresses along with the errors of failed lookups by address
+type TxBatchPage struct {
+	Txs    TxPage
+	Errors map[string]error
+}
+
+// Add appends the transactions that are not part of the page yet
+func (r *TxBatchPage) Add(txs TxPage) {
+	known := make(map[string]bool, len(r.Txs))
+	for _, tx := range r.Txs {
+		known[tx.ID] = true
+	}
+	for _, tx := range txs {
+		if !known[tx.ID] {
+			known[tx.ID] = true
+			r.Txs = append(r.Txs, tx)
+		}
+	}
+}
+
+// AddError records the failed lookup of an address
+func (r *TxBatchPage) AddError(address string, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]error)
+	}
+	r.Errors[address] = err
+}
+
+// AddPending appends pending transactions
+// that are not part of the page yet
+func (r *TxPage) AddPending(pending TxPage) {
+	known := make(map[string]bool, len(*r))
+	for _, tx := range *r {
+		known[tx.ID] = true
+	}
+	for _, tx := range pending {
+		if !known[tx.ID] {
+			*r = append(*r, tx)
+		}
+	}
+}
+
 // Amount is a positive decimal integer string.
 // It is written in the smallest possible unit (e.g. Wei, Satoshis)
 type Amount string
@@ -44,6 +106,8 @@ type Tx struct {
 	Block uint64 `json:"block"`
 	// Status of the transaction
 	Status string `json:"status"`
+	// Direction relative to the requested address (optional)
+	Direction string `json:"direction,omitempty"`
 	// Empty if the transaction was successful,
 	// else error explaining why the transaction failed (optional)
 	Error string `json:"error,omitempty"`
@@ -54,6 +118,120 @@ type Tx struct {
 	// Meta data object
 	Memo string      `json:"memo"`
 	Meta interface{} `json:"metadata"`
+	// Ordered operations if the transaction carries more than one.
+	// From, To and Meta always describe the first operation.
+	Ops []Op `json:"operations,omitempty"`
+}
+
+// Op describes a single operation of a transaction
+type Op struct {
+	// Type of metadata
+	Type string `json:"type"`
+	// Address of the operation sender
+	From string `json:"from"`
+	// Address of the operation recipient
+	To string `json:"to"`
+	// Meta data object
+	Meta interface{} `json:"metadata"`
+}
+
+// SetOps sets the operations of a transaction.
+// The first operation is copied into From, To and Meta,
+// the list is only kept if there is more than one.
+func (t *Tx) SetOps(ops []Op) {
+	if len(ops) == 0 {
+		return
+	}
+	t.From = ops[0].From
+	t.To = ops[0].To
+	t.Meta = ops[0].Meta
+	if len(ops) > 1 {
+		t.Ops = ops
+	} else {
+		t.Ops = nil
+	}
+}
+
+// Participants returns every address involved in the transaction:
+// senders and recipients of the transaction, its operations
+// and their token transfers. Empty addresses are left out.
+func (t *Tx) Participants() []string {
+	senders, recipients := t.parties()
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, address := range append(senders, recipients...) {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// DirectionOf returns the direction of the transaction relative to an
+// address, empty if the address is not involved. canonical converts the
+// addresses of the transaction before comparing them (optional).
+func (t *Tx) DirectionOf(address string, canonical func(string) string) string {
+	if canonical == nil {
+		canonical = func(address string) string { return address }
+	}
+	includes := func(addresses []string) bool {
+		for _, a := range addresses {
+			if a != "" && canonical(a) == address {
+				return true
+			}
+		}
+		return false
+	}
+	senders, recipients := t.parties()
+	sent, received := includes(senders), includes(recipients)
+	switch {
+	case sent && received:
+		return DirectionSelf
+	case sent:
+		return DirectionOutgoing
+	case received:
+		return DirectionIncoming
+	default:
+		return ""
+	}
+}
+
+// parties returns the senders and recipients of the
+// transaction, its operations and their metadata
+func (t *Tx) parties() (senders []string, recipients []string) {
+	senders = append(senders, t.From)
+	recipients = append(recipients, t.To)
+	from, to := metaParties(t.Meta)
+	senders, recipients = append(senders, from...), append(recipients, to...)
+	for _, op := range t.Ops {
+		from, to := metaParties(op.Meta)
+		senders = append(append(senders, op.From), from...)
+		recipients = append(append(recipients, op.To), to...)
+	}
+	return
+}
+
+// metaParties returns the senders and recipients of token metadata
+func metaParties(meta interface{}) (senders []string, recipients []string) {
+	switch m := meta.(type) {
+	case NativeTokenTransfer:
+		return []string{m.From}, []string{m.To}
+	case TokenTransfer:
+		return []string{m.From}, []string{m.To}
+	case TokenSwap:
+		return []string{m.Input.From, m.Output.From}, []string{m.Input.To, m.Output.To}
+	default:
+		return nil, nil
+	}
+}
+
+// SetDirection sets the direction of the transactions relative to an address.
+// canonical converts the addresses of the transactions (optional).
+func (r TxPage) SetDirection(address string, canonical func(string) string) {
+	for i := range r {
+		r[i].Direction = r[i].DirectionOf(address, canonical)
+	}
 }
 
 // Transfer describes the transfer of currency native to the platform
@@ -104,3 +282,28 @@ type ContractCall struct {
 	Input string `json:"input"`
 	Value string `json:"value"`
 }
+
+// Delegate describes staking or delegating funds.
+// Validator is empty if the funds are not bound to one.
+type Delegate struct {
+	Validator string `json:"validator"`
+	Value     Amount `json:"value"`
+}
+
+// Undelegate describes unstaking or undelegating funds
+type Undelegate struct {
+	Validator string `json:"validator"`
+	Value     Amount `json:"value"`
+}
+
+// ClaimRewards describes the withdrawal of staking rewards
+type ClaimRewards struct {
+	Validator string `json:"validator"`
+	Value     Amount `json:"value"`
+}
+
+// Vote describes votes cast for a candidate
+type Vote struct {
+	Candidate string `json:"candidate"`
+	Value     Amount `json:"value"`
+}
@@ -0,0 +1,45 @@
+package blockatlas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTx_Participants(t *testing.T) {
+	tx := Tx{
+		From: "sender",
+		To:   "contract",
+		Meta: TokenTransfer{From: "sender", To: "recipient", Value: "1"},
+		Ops: []Op{
+			{From: "sender", To: "contract", Meta: TokenTransfer{From: "sender", To: "recipient", Value: "1"}},
+			{From: "sender", To: "other", Meta: Transfer{Value: "2"}},
+		},
+	}
+	expected := []string{"sender", "contract", "recipient", "other"}
+	if got := tx.Participants(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestTx_DirectionOf(t *testing.T) {
+	tx := Tx{From: "a", To: "b"}
+	self := Tx{From: "a", To: "a"}
+	token := Tx{From: "a", To: "contract", Meta: TokenTransfer{From: "a", To: "c"}}
+	tests := []struct {
+		tx      *Tx
+		address string
+		want    string
+	}{
+		{&tx, "a", DirectionOutgoing},
+		{&tx, "b", DirectionIncoming},
+		{&self, "a", DirectionSelf},
+		{&tx, "c", ""},
+		{&token, "c", DirectionIncoming},
+		{&token, "a", DirectionOutgoing},
+	}
+	for _, test := range tests {
+		if got := test.tx.DirectionOf(test.address, nil); got != test.want {
+			t.Errorf("direction of %s: expected %q, got %q", test.address, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package upstream
+
+import (
+	"github.com/trustwallet/blockatlas"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults of the circuit breakers
+const (
+	DefaultBreakerWindow      = 20
+	DefaultBreakerThreshold   = 0.5
+	DefaultBreakerMinRequests = 10
+	DefaultBreakerOpenTimeout = 30 * time.Second
+)
+
+const msgBreakerOpen = "servers unavailable (circuit breaker open)"
+
+// BreakerState is the state of a circuit breaker
+type BreakerState string
+
+// States of a circuit breaker
+const (
+	// Requests are sent
+	StateClosed BreakerState = "closed"
+	// Requests fail fast
+	StateOpen BreakerState = "open"
+	// A single request probes if the source API recovered
+	StateHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig describes when a circuit breaker opens
+type BreakerConfig struct {
+	// Number of recent requests the error rate is computed of
+	Window int
+	// Error rate opening the breaker
+	Threshold float64
+	// Number of requests in the window before the breaker can open
+	MinRequests int
+	// Time until an open breaker lets a probe request through
+	OpenTimeout time.Duration
+}
+
+// Breaker is the circuit breaker of the source API of a platform.
+// It opens if the error rate of recent requests exceeds the threshold.
+type Breaker struct {
+	mu     sync.Mutex
+	handle string
+	conf   BreakerConfig
+	state  BreakerState
+
+	// Ring buffer of recent outcomes (true if failed)
+	outcomes []bool
+	next     int
+	requests int
+	failures int
+
+	openedAt time.Time
+	probing  bool
+
+	lastErr       string
+	lastErrAt     time.Time
+	lastSuccessAt time.Time
+}
+
+// BreakerStatus is a snapshot of a circuit breaker
+type BreakerStatus struct {
+	Handle        string       `json:"handle"`
+	State         BreakerState `json:"state"`
+	ErrorRate     float64      `json:"error_rate"`
+	LastError     string       `json:"last_error,omitempty"`
+	LastErrorAt   int64        `json:"last_error_at,omitempty"`
+	LastSuccessAt int64        `json:"last_success_at,omitempty"`
+}
+
+var breakers = struct {
+	sync.Mutex
+	m map[string]*Breaker
+}{m: make(map[string]*Breaker)}
+
+// GetBreaker returns the circuit breaker of the platform with the
+// given handle, shared by all its clients. The config of the
+// first call is kept.
+func GetBreaker(handle string, conf BreakerConfig) *Breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+	if b, ok := breakers.m[handle]; ok {
+		return b
+	}
+	b := NewBreaker(handle, conf)
+	breakers.m[handle] = b
+	return b
+}
+
+// Breakers returns the status of all circuit breakers ordered by handle
+func Breakers() []BreakerStatus {
+	breakers.Lock()
+	list := make([]*Breaker, 0, len(breakers.m))
+	for _, b := range breakers.m {
+		list = append(list, b)
+	}
+	breakers.Unlock()
+
+	statuses := make([]BreakerStatus, len(list))
+	for i, b := range list {
+		statuses[i] = b.Status()
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Handle < statuses[j].Handle
+	})
+	return statuses
+}
+
+// NewBreaker returns a closed circuit breaker
+func NewBreaker(handle string, conf BreakerConfig) *Breaker {
+	if conf.Window < 1 {
+		conf.Window = 1
+	}
+	return &Breaker{
+		handle:   handle,
+		conf:     conf,
+		state:    StateClosed,
+		outcomes: make([]bool, conf.Window),
+	}
+}
+
+// Allow returns an error if a request must not be sent.
+// Every allowed request must be followed by a call to Record or Ignore.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.conf.OpenTimeout {
+			return b.errOpen()
+		}
+		b.state = StateHalfOpen
+		fallthrough
+	case StateHalfOpen:
+		if b.probing {
+			return b.errOpen()
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed request.
+// err is nil if the request succeeded.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if err == nil {
+		b.lastSuccessAt = now
+	} else {
+		b.lastErr = err.Error()
+		b.lastErrAt = now
+	}
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if err != nil {
+			b.open(now)
+		} else {
+			b.reset()
+		}
+		return
+	}
+
+	// Replace the oldest outcome of the window
+	if b.requests == len(b.outcomes) {
+		if b.outcomes[b.next] {
+			b.failures--
+		}
+	} else {
+		b.requests++
+	}
+	b.outcomes[b.next] = err != nil
+	if err != nil {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+
+	if b.state == StateClosed && b.requests >= b.conf.MinRequests &&
+		b.errorRate() >= b.conf.Threshold {
+		b.open(now)
+	}
+}
+
+// Ignore releases an allowed request without outcome
+// (e.g. canceled by the caller)
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen {
+		b.probing = false
+	}
+}
+
+// Status returns a snapshot of the breaker
+func (b *Breaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.state
+	if state == StateOpen && time.Since(b.openedAt) >= b.conf.OpenTimeout {
+		// The next request probes
+		state = StateHalfOpen
+	}
+	return BreakerStatus{
+		Handle:        b.handle,
+		State:         state,
+		ErrorRate:     b.errorRate(),
+		LastError:     b.lastErr,
+		LastErrorAt:   unix(b.lastErrAt),
+		LastSuccessAt: unix(b.lastSuccessAt),
+	}
+}
+
+func (b *Breaker) open(now time.Time) {
+	b.state = StateOpen
+	b.openedAt = now
+}
+
+func (b *Breaker) reset() {
+	b.state = StateClosed
+	b.requests, b.failures, b.next = 0, 0, 0
+}
+
+func (b *Breaker) errorRate() float64 {
+	if b.requests == 0 {
+		return 0
+	}
+	return float64(b.failures) / float64(b.requests)
+}
+
+func (b *Breaker) errOpen() error {
+	return &blockatlas.Error{
+		Kind:     blockatlas.KindUnavailable,
+		Platform: b.handle,
+		Message:  msgBreakerOpen,
+	}
+}
+
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,59 @@
+package upstream
+
+import (
+	"fmt"
+	"github.com/trustwallet/blockatlas"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := NewBreaker("test", BreakerConfig{
+		Window:      4,
+		Threshold:   0.5,
+		MinRequests: 4,
+		OpenTimeout: 10 * time.Millisecond,
+	})
+	send := func(err error) {
+		if allowErr := b.Allow(); allowErr != nil {
+			t.Fatalf("request rejected in state %s", b.Status().State)
+		}
+		b.Record(err)
+	}
+
+	send(nil)
+	send(nil)
+	send(fmt.Errorf("timeout"))
+	if state := b.Status().State; state != StateClosed {
+		t.Fatalf("expected closed below min requests, got %s", state)
+	}
+	send(fmt.Errorf("timeout"))
+
+	status := b.Status()
+	if status.State != StateOpen || status.LastError != "timeout" || status.LastSuccessAt == 0 {
+		t.Fatalf("expected open breaker with last error, got %+v", status)
+	}
+	err := blockatlas.ToError(b.Allow())
+	if err.Kind != blockatlas.KindUnavailable || err.Platform != "test" {
+		t.Fatalf("expected fast unavailable error, got %v", err)
+	}
+
+	// A single probe is let through after the timeout
+	time.Sleep(20 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatal("probe rejected")
+	}
+	if err := b.Allow(); err == nil {
+		t.Fatal("second probe allowed")
+	}
+	b.Record(fmt.Errorf("still down"))
+	if state := b.Status().State; state != StateOpen {
+		t.Fatalf("expected open after failed probe, got %s", state)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	send(nil)
+	if status := b.Status(); status.State != StateClosed || status.ErrorRate != 0 {
+		t.Fatalf("expected closed after successful probe, got %+v", status)
+	}
+}
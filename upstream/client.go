@@ -0,0 +1,132 @@
+// Package upstream provides the HTTP clients used to
+// access the source APIs of platforms.
+package upstream
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"net/http"
+	"time"
+)
+
+// Defaults of the upstream clients
+const (
+	DefaultTimeout         = 10 * time.Second
+	DefaultRetries         = 2
+	DefaultRetryBackoff    = 250 * time.Millisecond
+	DefaultUserAgent       = "blockatlas"
+	DefaultMaxResponseSize = 16 << 20
+)
+
+// Config describes the behaviour of an upstream client
+type Config struct {
+	// Handle of the platform, enables the circuit breaker of the platform
+	Handle string
+	// Timeout of a single attempt of a request
+	Timeout time.Duration
+	// Number of retries of failed idempotent requests
+	Retries int
+	// Delay before the first retry, doubled after each retry
+	RetryBackoff time.Duration
+	// Requests per second, 0 disables rate limiting
+	RateLimit float64
+	// Requests that can be sent at once without waiting
+	RateBurst int
+	// User-Agent header of requests
+	UserAgent string
+	// Limit of the response body size in bytes
+	MaxResponseSize int64
+	// URLs of the source API, the first one is the primary endpoint
+	Endpoints []string
+	// Time a failed endpoint is skipped if there are others
+	CoolDown time.Duration
+	// Circuit breaker of the platform
+	Breaker BreakerConfig
+}
+
+// LoadConfig reads the config of the platform with the given handle
+// from viper (<handle>.api, <handle>.timeout, <handle>.retries,
+// <handle>.retry_backoff, <handle>.rate_limit, <handle>.rate_burst,
+// <handle>.user_agent, <handle>.max_response_size, <handle>.cool_down,
+// <handle>.breaker_window, <handle>.breaker_threshold,
+// <handle>.breaker_min_requests and <handle>.breaker_open_timeout).
+// Unset keys fall back to the defaults.
+func LoadConfig(handle string) Config {
+	key := func(name string) string {
+		return fmt.Sprintf("%s.%s", handle, name)
+	}
+
+	conf := Config{
+		Handle:          handle,
+		Timeout:         DefaultTimeout,
+		Retries:         DefaultRetries,
+		RetryBackoff:    DefaultRetryBackoff,
+		UserAgent:       DefaultUserAgent,
+		MaxResponseSize: DefaultMaxResponseSize,
+		Endpoints:       Endpoints(handle),
+		CoolDown:        DefaultCoolDown,
+		Breaker: BreakerConfig{
+			Window:      DefaultBreakerWindow,
+			Threshold:   DefaultBreakerThreshold,
+			MinRequests: DefaultBreakerMinRequests,
+			OpenTimeout: DefaultBreakerOpenTimeout,
+		},
+	}
+	if viper.IsSet(key("timeout")) {
+		conf.Timeout = viper.GetDuration(key("timeout"))
+	}
+	if viper.IsSet(key("retries")) {
+		conf.Retries = viper.GetInt(key("retries"))
+	}
+	if viper.IsSet(key("retry_backoff")) {
+		conf.RetryBackoff = viper.GetDuration(key("retry_backoff"))
+	}
+	if viper.IsSet(key("user_agent")) {
+		conf.UserAgent = viper.GetString(key("user_agent"))
+	}
+	if viper.IsSet(key("max_response_size")) {
+		conf.MaxResponseSize = viper.GetInt64(key("max_response_size"))
+	}
+	if viper.IsSet(key("cool_down")) {
+		conf.CoolDown = viper.GetDuration(key("cool_down"))
+	}
+	if viper.IsSet(key("breaker_window")) {
+		conf.Breaker.Window = viper.GetInt(key("breaker_window"))
+	}
+	if viper.IsSet(key("breaker_threshold")) {
+		conf.Breaker.Threshold = viper.GetFloat64(key("breaker_threshold"))
+	}
+	if viper.IsSet(key("breaker_min_requests")) {
+		conf.Breaker.MinRequests = viper.GetInt(key("breaker_min_requests"))
+	}
+	if viper.IsSet(key("breaker_open_timeout")) {
+		conf.Breaker.OpenTimeout = viper.GetDuration(key("breaker_open_timeout"))
+	}
+	conf.RateLimit = viper.GetFloat64(key("rate_limit"))
+	conf.RateBurst = viper.GetInt(key("rate_burst"))
+	return conf
+}
+
+// New returns the HTTP client of the platform with the given handle
+// configured by the <handle>.* viper keys
+func New(handle string) *http.Client {
+	return NewWithConfig(LoadConfig(handle))
+}
+
+// NewWithConfig returns an HTTP client with the given config
+func NewWithConfig(conf Config) *http.Client {
+	t := &Transport{
+		Base:   http.DefaultTransport,
+		Config: conf,
+	}
+	if conf.RateLimit > 0 {
+		t.bucket = newTokenBucket(conf.RateLimit, conf.RateBurst)
+	}
+	if len(conf.Endpoints) > 1 {
+		t.endpoints = newEndpointSet(conf.Endpoints, conf.CoolDown)
+	}
+	if conf.Handle != "" {
+		t.breaker = GetBreaker(conf.Handle, conf.Breaker)
+	}
+	return &http.Client{Transport: t}
+}
@@ -0,0 +1,140 @@
+package upstream
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/trustwallet/blockatlas/metrics"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_Retries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(r.UserAgent()))
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(Config{
+		Timeout:      time.Second,
+		Retries:      2,
+		RetryBackoff: time.Millisecond,
+		UserAgent:    "test-agent",
+	})
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("expected success after 3 calls, got %d after %d", res.StatusCode, calls)
+	}
+	if string(body) != "test-agent" {
+		t.Errorf("expected User-Agent test-agent, got %s", body)
+	}
+
+	// Non-idempotent requests are not retried
+	atomic.StoreInt32(&calls, 0)
+	res, err = client.Post(server.URL, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("expected a single failed call, got %d after %d", res.StatusCode, calls)
+	}
+}
+
+func TestTransport_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Chunked response without Content-Length
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(Config{MaxResponseSize: 10})
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if _, err := ioutil.ReadAll(res.Body); err != ErrTooLarge {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestTransport_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(Config{Timeout: 10 * time.Millisecond})
+	if _, err := client.Get(server.URL); err == nil {
+		t.Error("expected timeout")
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 2)
+	for i := 0; i < 2; i++ {
+		if delay := b.reserve(); delay != 0 {
+			t.Errorf("burst request %d delayed by %s", i, delay)
+		}
+	}
+	delay := b.reserve()
+	if delay < 90*time.Millisecond || delay > 100*time.Millisecond {
+		t.Errorf("expected delay of 100ms, got %s", delay)
+	}
+}
+
+func TestTransport_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(Config{Handle: "metrics-test"})
+	for i := 0; i < 2; i++ {
+		res, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	errors := testutil.ToFloat64(metrics.UpstreamErrors.WithLabelValues("metrics-test", "http_4xx"))
+	if errors != 2 {
+		t.Errorf("expected 2 errors, got %v", errors)
+	}
+}
+
+func TestIsTemporary(t *testing.T) {
+	tests := map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     false,
+		http.StatusInternalServerError: false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+	for status, expected := range tests {
+		if actual := isTemporary(&http.Response{StatusCode: status}, nil); actual != expected {
+			t.Errorf("status %d: expected temporary %t, got %t", status, expected, actual)
+		}
+	}
+	if isTemporary(nil, ErrTooLarge) {
+		t.Error("expected oversized responses not to be retried")
+	}
+}
@@ -0,0 +1,56 @@
+package upstream
+
+import (
+	"context"
+	"net/http"
+)
+
+// Get sends a GET request that is canceled when the context is done
+func Get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// WithContext returns a copy of the client that binds all its requests
+// to the context. It serves libraries that create requests on their own
+// without taking a context (e.g. the JSON-RPC client).
+func WithContext(ctx context.Context, client *http.Client) *http.Client {
+	c := *client
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.Transport = &contextTransport{ctx: ctx, base: base}
+	return &c
+}
+
+type idempotentKey struct{}
+
+// Idempotent marks the requests sent with the context as safe to repeat.
+// Read-only calls sent with POST (e.g. JSON-RPC) are then retried and
+// fail over to the next endpoint like GET requests. Their body is
+// replayed with GetBody, requests without it are not retried.
+func Idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotent says whether a request can be sent again
+func isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+}
+
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
@@ -0,0 +1,40 @@
+package upstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGet_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client := NewWithConfig(Config{Timeout: time.Minute})
+
+	start := time.Now()
+	if _, err := Get(ctx, client, server.URL); err == nil {
+		t.Fatal("expected error of canceled request")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("request not canceled with its context")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := WithContext(ctx, NewWithConfig(Config{}))
+	if _, err := client.Get(server.URL); err == nil {
+		t.Error("expected error of request bound to canceled context")
+	}
+}
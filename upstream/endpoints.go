@@ -0,0 +1,119 @@
+package upstream
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCoolDown is the time a failed endpoint is skipped
+const DefaultCoolDown = 30 * time.Second
+
+// Endpoints returns the URLs of the source API of the platform
+// with the given handle. <handle>.api is a single URL
+// or a list of URLs (comma or space separated in the environment).
+func Endpoints(handle string) []string {
+	var endpoints []string
+	for _, value := range viper.GetStringSlice(fmt.Sprintf("%s.api", handle)) {
+		for _, endpoint := range strings.Split(value, ",") {
+			if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints
+}
+
+// URL returns the primary endpoint of the platform with the given handle.
+// Clients build requests relative to it, the transport
+// of the platform sends them to the first healthy endpoint.
+func URL(handle string) string {
+	endpoints := Endpoints(handle)
+	if len(endpoints) == 0 {
+		return ""
+	}
+	return endpoints[0]
+}
+
+// endpointSet tracks the health of the endpoints of a platform
+type endpointSet struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	// Index of the endpoint in use
+	current  int
+	coolDown time.Duration
+}
+
+type endpoint struct {
+	url string
+	// Time until which the endpoint is skipped after a failure
+	failedUntil time.Time
+}
+
+func newEndpointSet(urls []string, coolDown time.Duration) *endpointSet {
+	s := &endpointSet{coolDown: coolDown}
+	for _, u := range urls {
+		s.endpoints = append(s.endpoints, &endpoint{url: u})
+	}
+	return s
+}
+
+// pick returns the endpoint in use if healthy, otherwise the next
+// healthy endpoint. If all endpoints failed recently,
+// the one that is available again first is returned.
+func (s *endpointSet) pick() *endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	next := s.endpoints[s.current]
+	for i := range s.endpoints {
+		j := (s.current + i) % len(s.endpoints)
+		e := s.endpoints[j]
+		if !now.Before(e.failedUntil) {
+			s.current = j
+			return e
+		}
+		if e.failedUntil.Before(next.failedUntil) {
+			next = e
+		}
+	}
+	return next
+}
+
+// report updates the health of an endpoint after a request
+func (s *endpointSet) report(e *endpoint, healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if healthy {
+		e.failedUntil = time.Time{}
+	} else {
+		e.failedUntil = time.Now().Add(s.coolDown)
+	}
+}
+
+// rewrite returns the request sent to an endpoint.
+// Requests not relative to the primary endpoint are kept as is,
+// sent is false for them since they don't go to the endpoint.
+func (s *endpointSet) rewrite(req *http.Request, e *endpoint) (r *http.Request, sent bool, err error) {
+	primary := s.endpoints[0].url
+	raw := req.URL.String()
+	if !strings.HasPrefix(raw, primary) {
+		return req, false, nil
+	}
+	if e.url == primary {
+		return req, true, nil
+	}
+	u, err := url.Parse(e.url + strings.TrimPrefix(raw, primary))
+	if err != nil {
+		return nil, false, err
+	}
+	r = req.Clone(req.Context())
+	r.URL = u
+	r.Host = ""
+	return r, true, nil
+}
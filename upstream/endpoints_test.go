@@ -0,0 +1,131 @@
+package upstream
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpoints(t *testing.T) {
+	viper.Set("test.api", "http://a/api, http://b/api")
+	expected := []string{"http://a/api", "http://b/api"}
+	if actual := Endpoints("test"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	viper.Set("test.api", []string{"http://c/api", "http://d/api"})
+	if actual := URL("test"); actual != "http://c/api" {
+		t.Errorf("expected primary endpoint http://c/api, got %s", actual)
+	}
+}
+
+func TestTransport_Failover(t *testing.T) {
+	var downCalls int
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downCalls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer up.Close()
+
+	client := NewWithConfig(Config{
+		Timeout:      time.Second,
+		Retries:      1,
+		RetryBackoff: time.Millisecond,
+		Endpoints:    []string{down.URL + "/v1", up.URL + "/v1"},
+		CoolDown:     time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		res, err := client.Get(down.URL + "/v1/accounts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK || string(body) != "/v1/accounts" {
+			t.Errorf("request %d: expected /v1/accounts from healthy endpoint, got %d %s",
+				i, res.StatusCode, body)
+		}
+	}
+	// The failed endpoint is skipped during the cool-down
+	if downCalls != 1 {
+		t.Errorf("expected 1 call to the failed endpoint, got %d", downCalls)
+	}
+}
+
+func TestTransport_FailoverIdempotentPost(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer up.Close()
+
+	client := NewWithConfig(Config{
+		Timeout:      time.Second,
+		Retries:      1,
+		RetryBackoff: time.Millisecond,
+		Endpoints:    []string{down.URL, up.URL},
+		CoolDown:     time.Minute,
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, down.URL, strings.NewReader(`{"method":"getBalance"}`))
+	res, err := client.Do(req.WithContext(Idempotent(context.Background())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	// The body is sent again to the healthy endpoint
+	if res.StatusCode != http.StatusOK || string(body) != `{"method":"getBalance"}` {
+		t.Errorf("expected the request body from healthy endpoint, got %d %s", res.StatusCode, body)
+	}
+}
+
+func TestTransport_FailoverOtherURL(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer up.Close()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer other.Close()
+
+	client := NewWithConfig(Config{
+		Timeout:      time.Second,
+		RetryBackoff: time.Millisecond,
+		Endpoints:    []string{up.URL + "/v1", up.URL + "/v2"},
+		CoolDown:     time.Minute,
+	})
+
+	// Failures of URLs outside the endpoints don't affect their health
+	res, err := client.Get(other.URL + "/rpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	res, err = client.Get(up.URL + "/v1/accounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "/v1/accounts" {
+		t.Errorf("expected request to the primary endpoint, got %s", body)
+	}
+}
@@ -0,0 +1,258 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"github.com/trustwallet/blockatlas"
+	"github.com/trustwallet/blockatlas/metrics"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrTooLarge signals that a response exceeded the size limit
+var ErrTooLarge = errors.New("upstream: response too large")
+
+// Transport is an http.RoundTripper adding timeouts, retries,
+// rate limiting, a User-Agent, a response size limit and
+// failover between endpoints to the requests of a base transport
+type Transport struct {
+	Base      http.RoundTripper
+	Config    Config
+	bucket    *tokenBucket
+	endpoints *endpointSet
+	breaker   *Breaker
+}
+
+// RoundTrip sends a request. Idempotent requests (GET, HEAD and
+// requests marked with Idempotent) are retried on connection errors
+// and on temporary HTTP errors.
+// With multiple endpoints, requests go to the first healthy one
+// and endpoints failing with connection errors or 5xx are skipped
+// for the cool-down period.
+// While the circuit breaker of the platform is open, requests fail fast.
+// Latency and errors are reported to the metrics of the platform.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.guardedRoundTrip(req)
+	if t.Config.Handle != "" {
+		metrics.UpstreamDuration.WithLabelValues(t.Config.Handle).
+			Observe(time.Since(start).Seconds())
+		if reason := errorReason(req, res, err); reason != "" {
+			metrics.UpstreamErrors.WithLabelValues(t.Config.Handle, reason).Inc()
+		}
+	}
+	return res, err
+}
+
+// guardedRoundTrip sends a request through the circuit breaker
+func (t *Transport) guardedRoundTrip(req *http.Request) (*http.Response, error) {
+	if t.breaker == nil {
+		return t.roundTrip(req)
+	}
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	res, err := t.roundTrip(req)
+	switch {
+	case req.Context().Err() != nil, err == ErrTooLarge:
+		// Says nothing about the health of the source API
+		t.breaker.Ignore()
+	case err != nil:
+		t.breaker.Record(err)
+	case res.StatusCode >= http.StatusInternalServerError:
+		t.breaker.Record(blockatlas.ErrStatus(res.StatusCode))
+	default:
+		t.breaker.Record(nil)
+	}
+	return res, err
+}
+
+func (t *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request
+	req = req.Clone(req.Context())
+	if t.Config.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", t.Config.UserAgent)
+	}
+
+	attempts := 1
+	if isIdempotent(req) {
+		attempts += t.Config.Retries
+	}
+
+	backoff := t.Config.RetryBackoff
+	for i := 1; ; i++ {
+		res, err := t.attemptEndpoint(req)
+		if i >= attempts || !isTemporary(res, err) {
+			return res, err
+		}
+		if res != nil {
+			// Drain the body to reuse the connection
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		if err := sleep(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+		// The body has been read by the previous attempt
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// attemptEndpoint sends a request once to
+// the current endpoint and tracks its health
+func (t *Transport) attemptEndpoint(req *http.Request) (*http.Response, error) {
+	if t.endpoints == nil {
+		return t.attempt(req)
+	}
+	e := t.endpoints.pick()
+	r, sent, err := t.endpoints.rewrite(req, e)
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.attempt(r)
+	switch {
+	case !sent:
+		// Not a request to the endpoint (e.g. another API of the platform)
+	case req.Context().Err() != nil:
+		// Canceled by the caller, says nothing about the endpoint
+	case err != nil:
+		t.endpoints.report(e, err == ErrTooLarge)
+	default:
+		t.endpoints.report(e, res.StatusCode < http.StatusInternalServerError)
+	}
+	return res, err
+}
+
+// attempt sends a request once
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.bucket != nil {
+		if err := t.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if t.Config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.Config.Timeout)
+	}
+	res, err := t.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	limit := t.Config.MaxResponseSize
+	if limit <= 0 {
+		limit = -1
+	} else if res.ContentLength > limit {
+		_ = res.Body.Close()
+		cancel()
+		return nil, ErrTooLarge
+	}
+	// The timeout also covers reading the body
+	res.Body = &body{ReadCloser: res.Body, cancel: cancel, remaining: limit}
+	return res, nil
+}
+
+// isTemporary says whether a request might succeed if repeated.
+// Rate limits (429) are not retried since an immediate repeat
+// only adds to the load, neither are internal errors (500)
+// which are mostly caused by the request itself.
+func isTemporary(res *http.Response, err error) bool {
+	if err != nil {
+		return err != ErrTooLarge
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// errorReason classifies the outcome of a request for the metrics,
+// it is empty if the request succeeded
+func errorReason(req *http.Request, res *http.Response, err error) string {
+	if err != nil {
+		if e, ok := err.(*blockatlas.Error); ok && e.Message == msgBreakerOpen {
+			return "breaker_open"
+		}
+		if err == ErrTooLarge {
+			return "too_large"
+		}
+		if req.Context().Err() != nil {
+			return "canceled"
+		}
+		if e, ok := err.(net.Error); ok && e.Timeout() {
+			return "timeout"
+		}
+		return "connection"
+	}
+	switch {
+	case res.StatusCode >= http.StatusInternalServerError:
+		return "http_5xx"
+	case res.StatusCode >= http.StatusBadRequest:
+		return "http_4xx"
+	}
+	return ""
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// body is a response body limited in size
+// that releases the context of the request on close
+type body struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+	// Bytes left to read, -1 if unlimited
+	remaining int64
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return b.ReadCloser.Read(p)
+	}
+	if b.remaining == 0 {
+		// Fail if there is data beyond the limit
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Bech32Decode checks a Bech32 string (BIP-173) and returns
+// its human-readable part and the decoded bytes of its data part.
+// The returned string is the canonical lower-case encoding.
+//  - "cosmos1..." => "cosmos", 20 bytes
+func Bech32Decode(s string) (canonical string, hrp string, data []byte, err error) {
+	if len(s) > 90 {
+		return "", "", nil, fmt.Errorf("bech32 string too long: %s", s)
+	}
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return "", "", nil, fmt.Errorf("mixed-case bech32 string: %s", s)
+	}
+
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+7 > len(lower) {
+		return "", "", nil, fmt.Errorf("invalid bech32 separator: %s", s)
+	}
+	hrp = lower[:sep]
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", "", nil, fmt.Errorf("invalid bech32 prefix: %s", s)
+		}
+	}
+
+	values := make([]byte, len(lower)-sep-1)
+	for i, c := range lower[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v == -1 {
+			return "", "", nil, fmt.Errorf("invalid bech32 character: %s", s)
+		}
+		values[i] = byte(v)
+	}
+	if bech32Polymod(append(bech32ExpandHRP(hrp), values...)) != 1 {
+		return "", "", nil, fmt.Errorf("invalid bech32 checksum: %s", s)
+	}
+
+	// Strip the 6 checksum characters and regroup to bytes
+	data, err = convertBits(values[:len(values)-6], 5, 8)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return lower, hrp, data, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		out = append(out, byte(c)>>5)
+	}
+	out = append(out, 0)
+	for _, c := range hrp {
+		out = append(out, byte(c)&31)
+	}
+	return out
+}
+
+// convertBits regroups groups of fromBits bits into groups of toBits bits.
+// Fails on non-zero padding.
+func convertBits(data []byte, fromBits, toBits uint) ([]byte, error) {
+	var acc uint
+	var bits uint
+	var out []byte
+	maxV := uint(1)<<toBits - 1
+	for _, v := range data {
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxV))
+		}
+	}
+	if bits >= fromBits || (acc<<(toBits-bits))&maxV != 0 {
+		return nil, fmt.Errorf("invalid bech32 padding")
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package util
+
+import "testing"
+
+func TestBech32Decode(t *testing.T) {
+	for _, test := range []struct {
+		input     string
+		canonical string
+		hrp       string
+		length    int
+	}{
+		{"A12UEL5L", "a12uel5l", "a", 0},
+		{"cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl", "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl", "cosmos", 20},
+		{"BNB1GRPF0955H0YKZQ3AR5NMUM7Y6GDFL6LXFN46H2", "bnb1grpf0955h0ykzq3ar5nmum7y6gdfl6lxfn46h2", "bnb", 20},
+	} {
+		canonical, hrp, data, err := Bech32Decode(test.input)
+		if err != nil {
+			t.Errorf("%s: %s", test.input, err)
+			continue
+		}
+		if canonical != test.canonical || hrp != test.hrp || len(data) != test.length {
+			t.Errorf("%s: got %s, %s, %d bytes", test.input, canonical, hrp, len(data))
+		}
+	}
+
+	for _, input := range []string{
+		"cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrm",
+		"cosmos1Rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
+		"cosmos1bw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
+		"1qzzfhee",
+		"cosmos",
+	} {
+		if _, _, _, err := Bech32Decode(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
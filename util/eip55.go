@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"strings"
+)
+
+// EIP55Checksum returns the mixed-case checksum encoding (EIP-55)
+// of a 20 byte hex address with "0x" prefix.
+// All lower- or upper-case addresses are accepted as is,
+// mixed-case addresses must carry a valid checksum.
+//  - "0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae" => "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"
+func EIP55Checksum(address string) (string, error) {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return "", fmt.Errorf("not a hex address: %s", address)
+	}
+	digits := address[2:]
+	lower := strings.ToLower(digits)
+	if _, err := hex.DecodeString(lower); err != nil {
+		return "", fmt.Errorf("not a hex address: %s", address)
+	}
+
+	// Hex digits are upper-case if the nibble
+	// of the hash at the same position is >= 8
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	sum := hash.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	isMixed := digits != lower && digits != strings.ToUpper(digits)
+	if isMixed && digits != string(checksummed) {
+		return "", fmt.Errorf("invalid checksum: %s", address)
+	}
+	return "0x" + string(checksummed), nil
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEIP55Checksum(t *testing.T) {
+	// Test vectors of EIP-55
+	for _, expected := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		for _, input := range []string{
+			expected,
+			strings.ToLower(expected),
+			"0x" + strings.ToUpper(expected[2:]),
+		} {
+			actual, err := EIP55Checksum(input)
+			if err != nil {
+				t.Errorf("%s: %s", input, err)
+			} else if actual != expected {
+				t.Errorf("%s: expected %s, got %s", input, expected, actual)
+			}
+		}
+	}
+
+	for _, input := range []string{
+		"0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg",
+	} {
+		if _, err := EIP55Checksum(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package util
+
+// Contains says whether a list of strings contains s
+func Contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package util
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	list := []string{"transfer", "delegate"}
+	if !Contains(list, "delegate") {
+		t.Error("expected list to contain delegate")
+	}
+	if Contains(list, "vote") || Contains(nil, "") {
+		t.Error("expected list not to contain vote")
+	}
+}